@@ -0,0 +1,409 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	db "github.com/backendn/clearance_system/db/sqlc"
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+)
+
+type approverGroupRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+}
+
+type approverGroupMemberRequest struct {
+	StaffID      int64 `json:"staff_id" binding:"required,min=1"`
+	IsSupervisor bool  `json:"is_supervisor"`
+}
+
+type clearanceItemPoolRequest struct {
+	ApproverPool       string `json:"approver_pool" binding:"required,oneof=none department group"`
+	ApproverGroupID    int64  `json:"approver_group_id"` // required for the group pool
+	AssignmentStrategy string `json:"assignment_strategy" binding:"omitempty,oneof=claim round_robin least_loaded"`
+}
+
+type assignClearanceRecordRequest struct {
+	StaffID int64 `json:"staff_id" binding:"required,min=1"`
+}
+
+// approverToNotify returns who hears about a record: its assignee when it
+// has one, otherwise the item's approver.
+func approverToNotify(approverID int64, assigned sql.NullInt64) int64 {
+	if assigned.Valid {
+		return assigned.Int64
+	}
+	return approverID
+}
+
+// POST /admins/approver_groups
+func (server *Server) createApproverGroup(ctx *gin.Context) {
+	var req approverGroupRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	group, err := server.store.CreateApproverGroup(ctx, db.CreateApproverGroupParams{
+		Name:        req.Name,
+		Description: req.Description,
+	})
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			ctx.JSON(http.StatusBadRequest, errorMessage("approver group name already exists"))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, group)
+}
+
+// GET /admins/approver_groups
+func (server *Server) listApproverGroups(ctx *gin.Context) {
+	groups, err := server.store.ListApproverGroups(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, groups)
+}
+
+// DELETE /admins/approver_groups/:id
+func (server *Server) deleteApproverGroup(ctx *gin.Context) {
+	id, err := getIDParam(ctx)
+	if err != nil {
+		return
+	}
+
+	used, err := server.store.CountItemsUsingApproverGroup(ctx, ToNullInt64(id))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if used > 0 {
+		ctx.JSON(http.StatusConflict, errorMessage("approver group is still used by clearance items"))
+		return
+	}
+
+	if err := server.store.DeleteApproverGroup(ctx, id); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "deleted"})
+}
+
+// approverGroup loads the group from the :id parameter. It writes the error
+// response itself.
+func (server *Server) approverGroup(ctx *gin.Context) (db.ApproverGroup, bool) {
+	id, err := getIDParam(ctx)
+	if err != nil {
+		return db.ApproverGroup{}, false
+	}
+
+	group, err := server.store.GetApproverGroup(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorMessage("approver group not found"))
+			return db.ApproverGroup{}, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return db.ApproverGroup{}, false
+	}
+
+	return group, true
+}
+
+// GET /admins/approver_groups/:id/members
+func (server *Server) listApproverGroupMembers(ctx *gin.Context) {
+	group, ok := server.approverGroup(ctx)
+	if !ok {
+		return
+	}
+
+	members, err := server.store.ListApproverGroupMembers(ctx, group.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, members)
+}
+
+// POST /admins/approver_groups/:id/members
+// Adds a member, or updates the supervisor flag of an existing one.
+func (server *Server) addApproverGroupMember(ctx *gin.Context) {
+	group, ok := server.approverGroup(ctx)
+	if !ok {
+		return
+	}
+
+	var req approverGroupMemberRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if _, err := server.store.GetStaffUser(ctx, req.StaffID); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorMessage("invalid staff ID"))
+		return
+	}
+
+	member, err := server.store.UpsertApproverGroupMember(ctx, db.UpsertApproverGroupMemberParams{
+		GroupID:      group.ID,
+		StaffID:      req.StaffID,
+		IsSupervisor: req.IsSupervisor,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, member)
+}
+
+// DELETE /admins/approver_groups/:id/members/:staff_id
+// Records already assigned to the member stay with them until reassigned.
+func (server *Server) removeApproverGroupMember(ctx *gin.Context) {
+	group, ok := server.approverGroup(ctx)
+	if !ok {
+		return
+	}
+
+	staffID, err := strconv.ParseInt(ctx.Param("staff_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorMessage("invalid staff_id parameter"))
+		return
+	}
+
+	removed, err := server.store.DeleteApproverGroupMember(ctx, db.DeleteApproverGroupMemberParams{
+		GroupID: group.ID,
+		StaffID: staffID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if removed == 0 {
+		ctx.JSON(http.StatusNotFound, errorMessage("staff user is not a member of the group"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "deleted"})
+}
+
+// PUT /admins/clearance_items/:id/pool
+// Switches an item between its single approver and an approver pool.
+// Existing assignments are kept.
+func (server *Server) setClearanceItemPool(ctx *gin.Context) {
+	id, err := getIDParam(ctx)
+	if err != nil {
+		return
+	}
+
+	var req clearanceItemPoolRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if req.AssignmentStrategy == "" {
+		req.AssignmentStrategy = db.AssignmentClaim
+	}
+
+	arg := db.SetClearanceItemPoolParams{
+		ID:                 id,
+		ApproverPool:       req.ApproverPool,
+		AssignmentStrategy: req.AssignmentStrategy,
+	}
+
+	if req.ApproverPool == db.ApproverPoolGroup {
+		if _, err := server.store.GetApproverGroup(ctx, req.ApproverGroupID); err != nil {
+			ctx.JSON(http.StatusBadRequest, errorMessage("invalid approver group ID"))
+			return
+		}
+		arg.ApproverGroupID = ToNullInt64(req.ApproverGroupID)
+	} else if req.ApproverGroupID != 0 {
+		ctx.JSON(http.StatusBadRequest, errorMessage("approver_group_id is only used with the group pool"))
+		return
+	}
+
+	item, err := server.store.SetClearanceItemPool(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorMessage("clearance item not found"))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, item)
+}
+
+// pooledRecord loads the record from the :id parameter together with its
+// item and checks that the item is approved by a pool and that the record
+// is still open. It writes the error response itself.
+func (server *Server) pooledRecord(ctx *gin.Context) (db.ClearanceRecord, db.GetRecordItemRow, bool) {
+	id, err := getIDParam(ctx)
+	if err != nil {
+		return db.ClearanceRecord{}, db.GetRecordItemRow{}, false
+	}
+
+	record, err := server.store.GetClearanceRecord(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorMessage("clearance record not found"))
+			return db.ClearanceRecord{}, db.GetRecordItemRow{}, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return db.ClearanceRecord{}, db.GetRecordItemRow{}, false
+	}
+
+	item, err := server.store.GetRecordItem(ctx, id)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return db.ClearanceRecord{}, db.GetRecordItemRow{}, false
+	}
+
+	if item.ApproverPool == db.ApproverPoolNone {
+		ctx.JSON(http.StatusConflict, errorCode("not_pooled",
+			errors.New("this clearance item is decided by its approver, not by a pool")))
+		return db.ClearanceRecord{}, db.GetRecordItemRow{}, false
+	}
+	if db.IsFinalRecordStatus(record.Status) {
+		ctx.JSON(http.StatusConflict, errorCode("record_closed",
+			fmt.Errorf("clearance record is already %s", record.Status)))
+		return db.ClearanceRecord{}, db.GetRecordItemRow{}, false
+	}
+
+	return record, item, true
+}
+
+// POST /clearance_records/:id/claim
+// Takes an unassigned pooled record. Only one pool member can win.
+func (server *Server) claimClearanceRecord(ctx *gin.Context) {
+	record, item, ok := server.pooledRecord(ctx)
+	if !ok {
+		return
+	}
+
+	payload := authPayload(ctx)
+	member, err := server.store.IsItemPoolMember(ctx, db.IsItemPoolMemberParams{
+		StaffID: payload.UserID,
+		ItemID:  item.ItemID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if !member {
+		forbidden(ctx)
+		return
+	}
+
+	claimed, err := server.store.ClaimClearanceRecord(ctx, db.ClaimClearanceRecordParams{
+		ID:              record.ID,
+		AssignedStaffID: ToNullInt64(payload.UserID),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusConflict, errorCode("already_claimed",
+				errors.New("clearance record was already claimed")))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, claimed)
+}
+
+// POST /clearance_records/:id/release
+// Hands a record the caller was assigned back to the pool.
+func (server *Server) releaseClearanceRecord(ctx *gin.Context) {
+	record, _, ok := server.pooledRecord(ctx)
+	if !ok {
+		return
+	}
+
+	if !record.AssignedStaffID.Valid || record.AssignedStaffID.Int64 != authPayload(ctx).UserID {
+		forbidden(ctx)
+		return
+	}
+
+	released, err := server.store.AssignClearanceRecord(ctx, db.AssignClearanceRecordParams{ID: record.ID})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, released)
+}
+
+// POST /clearance_records/:id/assign
+// Lets a supervisor hand a pooled record to a pool member or to the item's
+// approver, whether or not it was claimed.
+func (server *Server) assignClearanceRecord(ctx *gin.Context) {
+	record, item, ok := server.pooledRecord(ctx)
+	if !ok {
+		return
+	}
+
+	var req assignClearanceRecordRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	allowed, err := server.canSuperviseRecord(ctx, authPayload(ctx), item)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if !allowed {
+		forbidden(ctx)
+		return
+	}
+
+	if req.StaffID != item.ApproverStaffID {
+		member, err := server.store.IsItemPoolMember(ctx, db.IsItemPoolMemberParams{
+			StaffID: req.StaffID,
+			ItemID:  item.ItemID,
+		})
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		if !member {
+			ctx.JSON(http.StatusBadRequest, errorMessage("staff user is not a member of the item's approver pool"))
+			return
+		}
+	}
+
+	assigned, err := server.store.AssignClearanceRecord(ctx, db.AssignClearanceRecordParams{
+		ID:              record.ID,
+		AssignedStaffID: ToNullInt64(req.StaffID),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.sendNotification(ctx, req.StaffID, 0,
+		fmt.Sprintf("Clearance record %d for '%s' was assigned to you.", record.ID, item.Title))
+	if record.AssignedStaffID.Valid && record.AssignedStaffID.Int64 != req.StaffID {
+		server.sendNotification(ctx, record.AssignedStaffID.Int64, 0,
+			fmt.Sprintf("Clearance record %d for '%s' was reassigned.", record.ID, item.Title))
+	}
+
+	ctx.JSON(http.StatusOK, assigned)
+}
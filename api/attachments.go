@@ -0,0 +1,311 @@
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strconv"
+
+	db "github.com/backendn/clearance_system/db/sqlc"
+	"github.com/backendn/clearance_system/storage"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// sniffLen is the number of bytes http.DetectContentType looks at.
+const sniffLen = 512
+
+// allowedAttachmentTypes are the content types accepted for uploads,
+// as sniffed from the file content.
+var allowedAttachmentTypes = map[string]bool{
+	"application/pdf": true,
+	"image/png":       true,
+	"image/jpeg":      true,
+}
+
+var errLastRequiredAttachment = errors.New("the item requires an attachment; upload a replacement before deleting the last one")
+
+// viewableRecord loads the record from the :id parameter and checks that
+// the caller may see it. It writes the error response itself.
+func (server *Server) viewableRecord(ctx *gin.Context) (db.ClearanceRecord, bool) {
+	id, err := getIDParam(ctx)
+	if err != nil {
+		return db.ClearanceRecord{}, false
+	}
+
+	record, err := server.store.GetClearanceRecord(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorMessage("clearance record not found"))
+			return db.ClearanceRecord{}, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return db.ClearanceRecord{}, false
+	}
+
+	allowed, err := server.canViewRecord(ctx, authPayload(ctx), record)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return db.ClearanceRecord{}, false
+	}
+	if !allowed {
+		forbidden(ctx)
+		return db.ClearanceRecord{}, false
+	}
+
+	return record, true
+}
+
+// recordAttachment loads the attachment from the :attachment_id parameter
+// and checks that it belongs to record.
+func (server *Server) recordAttachment(ctx *gin.Context, record db.ClearanceRecord) (db.RecordAttachment, bool) {
+	attachmentID, err := strconv.ParseInt(ctx.Param("attachment_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorMessage("invalid attachment_id parameter"))
+		return db.RecordAttachment{}, false
+	}
+
+	attachment, err := server.store.GetRecordAttachment(ctx, attachmentID)
+	if err != nil && err != sql.ErrNoRows {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return db.RecordAttachment{}, false
+	}
+	if err == sql.ErrNoRows || attachment.RecordID != record.ID {
+		ctx.JSON(http.StatusNotFound, errorMessage("attachment not found"))
+		return db.RecordAttachment{}, false
+	}
+
+	return attachment, true
+}
+
+// formFile reads the optional "file" field of a multipart request, limiting
+// the body to the configured upload size. It returns a nil header when no
+// file was sent and writes the error response itself.
+func (server *Server) formFile(ctx *gin.Context) (*multipart.FileHeader, bool) {
+	maxSize := server.config.MaxUploadSize
+	tooLarge := fmt.Errorf("file exceeds the maximum size of %d bytes", maxSize)
+
+	// leave some room for the multipart framing around the file
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxSize+(1<<20))
+
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			ctx.JSON(http.StatusRequestEntityTooLarge, errorCode("file_too_large", tooLarge))
+			return nil, false
+		}
+		if errors.Is(err, http.ErrMissingFile) {
+			return nil, true
+		}
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return nil, false
+	}
+	if header.Size > maxSize {
+		ctx.JSON(http.StatusRequestEntityTooLarge, errorCode("file_too_large", tooLarge))
+		return nil, false
+	}
+	if header.Size == 0 {
+		ctx.JSON(http.StatusBadRequest, errorMessage("file is empty"))
+		return nil, false
+	}
+
+	return header, true
+}
+
+// storeRecordFile checks the uploaded file's content type, writes it to the
+// attachment storage and returns the row to insert for it. The caller must
+// delete the stored object if inserting the row fails.
+func (server *Server) storeRecordFile(ctx *gin.Context, record db.ClearanceRecord, header *multipart.FileHeader) (db.CreateRecordAttachmentParams, bool) {
+	file, err := header.Open()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return db.CreateRecordAttachmentParams{}, false
+	}
+	defer file.Close()
+
+	// the content type comes from the bytes, never from the client
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return db.CreateRecordAttachmentParams{}, false
+	}
+	head = head[:n]
+
+	contentType, _, _ := mime.ParseMediaType(http.DetectContentType(head))
+	if !allowedAttachmentTypes[contentType] {
+		ctx.JSON(http.StatusUnsupportedMediaType, errorCode("unsupported_media_type",
+			fmt.Errorf("files of type %q are not accepted; upload a PDF, PNG or JPEG", contentType)))
+		return db.CreateRecordAttachmentParams{}, false
+	}
+
+	key := fmt.Sprintf("records/%d/%s", record.ID, uuid.NewString())
+	hash := sha256.New()
+	body := io.TeeReader(io.MultiReader(bytes.NewReader(head), file), hash)
+
+	if err := server.storage.Put(ctx, key, body, header.Size, contentType); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorMessage("failed to store attachment"))
+		return db.CreateRecordAttachmentParams{}, false
+	}
+
+	fileName := filepath.Base(header.Filename)
+	if len(fileName) > 255 {
+		fileName = fileName[len(fileName)-255:]
+	}
+
+	payload := authPayload(ctx)
+	return db.CreateRecordAttachmentParams{
+		RecordID:       record.ID,
+		StorageKey:     key,
+		FileName:       fileName,
+		ContentType:    contentType,
+		SizeBytes:      header.Size,
+		ChecksumSha256: hex.EncodeToString(hash.Sum(nil)),
+		UploadedByRole: payload.Role,
+		UploadedByID:   payload.UserID,
+		Source:         db.AttachmentSourceRecord,
+	}, true
+}
+
+// POST /clearance_records/:id/attachments (multipart form, field "file")
+func (server *Server) uploadRecordAttachment(ctx *gin.Context) {
+	record, ok := server.viewableRecord(ctx)
+	if !ok {
+		return
+	}
+
+	header, ok := server.formFile(ctx)
+	if !ok {
+		return
+	}
+	if header == nil {
+		ctx.JSON(http.StatusBadRequest, errorMessage("a file is required in the \"file\" form field"))
+		return
+	}
+
+	arg, ok := server.storeRecordFile(ctx, record, header)
+	if !ok {
+		return
+	}
+
+	attachment, err := server.store.CreateRecordAttachment(ctx, arg)
+	if err != nil {
+		// don't leave orphaned objects behind
+		_ = server.storage.Delete(ctx, arg.StorageKey)
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, attachment)
+}
+
+// GET /clearance_records/:id/attachments
+func (server *Server) listRecordAttachments(ctx *gin.Context) {
+	record, ok := server.viewableRecord(ctx)
+	if !ok {
+		return
+	}
+
+	attachments, err := server.store.ListRecordAttachments(ctx, record.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, attachments)
+}
+
+// GET /clearance_records/:id/attachments/:attachment_id
+func (server *Server) downloadRecordAttachment(ctx *gin.Context) {
+	record, ok := server.viewableRecord(ctx)
+	if !ok {
+		return
+	}
+
+	attachment, ok := server.recordAttachment(ctx, record)
+	if !ok {
+		return
+	}
+
+	file, err := server.storage.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, errorMessage("attachment content is missing"))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorMessage("failed to read attachment"))
+		return
+	}
+	defer file.Close()
+
+	ctx.DataFromReader(http.StatusOK, attachment.SizeBytes, attachment.ContentType, file, map[string]string{
+		"Content-Disposition":    mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}),
+		"X-Content-Type-Options": "nosniff",
+		"ETag":                   `"` + attachment.ChecksumSha256 + `"`,
+		"X-Checksum-Sha256":      attachment.ChecksumSha256,
+	})
+}
+
+// DELETE /clearance_records/:id/attachments/:attachment_id
+func (server *Server) deleteRecordAttachment(ctx *gin.Context) {
+	record, ok := server.viewableRecord(ctx)
+	if !ok {
+		return
+	}
+
+	attachment, ok := server.recordAttachment(ctx, record)
+	if !ok {
+		return
+	}
+
+	// only the uploader or an admin may remove a file
+	payload := authPayload(ctx)
+	if payload.Role != "admin" &&
+		(attachment.UploadedByRole != payload.Role || attachment.UploadedByID != payload.UserID) {
+		forbidden(ctx)
+		return
+	}
+
+	// a record past pending or rejected relies on its file; the last one
+	// can only go once the record is back with the student
+	if attachment.Source == db.AttachmentSourceRecord &&
+		record.Status != db.RecordStatusPending && record.Status != db.RecordStatusRejected {
+		item, err := server.store.GetRecordItem(ctx, record.ID)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		if item.RequiresAttachment {
+			count, err := server.store.CountRecordAttachments(ctx, record.ID)
+			if err != nil {
+				ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+				return
+			}
+			if count <= 1 {
+				ctx.JSON(http.StatusConflict, errorCode("attachment_required", errLastRequiredAttachment))
+				return
+			}
+		}
+	}
+
+	if err := server.store.DeleteRecordAttachment(ctx, attachment.ID); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// the row is gone, so a failure here only leaves an unreachable object
+	if err := server.storage.Delete(ctx, attachment.StorageKey); err != nil {
+		ctx.Error(err)
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "deleted"})
+}
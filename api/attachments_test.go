@@ -0,0 +1,87 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"testing"
+
+	db "github.com/backendn/clearance_system/db/sqlc"
+	"github.com/backendn/clearance_system/util"
+	"github.com/stretchr/testify/require"
+)
+
+// attachmentStore serves one record of an item that requires an
+// attachment, together with its files.
+type attachmentStore struct {
+	db.Store
+	record      db.ClearanceRecord
+	attachments map[int64]db.RecordAttachment
+}
+
+func (s *attachmentStore) GetClearanceRecord(_ context.Context, id int64) (db.ClearanceRecord, error) {
+	if id != s.record.ID {
+		return db.ClearanceRecord{}, sql.ErrNoRows
+	}
+	return s.record, nil
+}
+
+func (s *attachmentStore) GetRecordAttachment(_ context.Context, id int64) (db.RecordAttachment, error) {
+	attachment, ok := s.attachments[id]
+	if !ok {
+		return db.RecordAttachment{}, sql.ErrNoRows
+	}
+	return attachment, nil
+}
+
+func (s *attachmentStore) GetRecordItem(_ context.Context, _ int64) (db.GetRecordItemRow, error) {
+	return db.GetRecordItemRow{RequiresAttachment: true}, nil
+}
+
+func (s *attachmentStore) CountRecordAttachments(_ context.Context, _ int64) (int64, error) {
+	var count int64
+	for _, attachment := range s.attachments {
+		if attachment.Source == db.AttachmentSourceRecord {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (s *attachmentStore) DeleteRecordAttachment(_ context.Context, id int64) error {
+	delete(s.attachments, id)
+	return nil
+}
+
+func TestDeleteLastRequiredAttachment(t *testing.T) {
+	newStore := func(status string) *attachmentStore {
+		return &attachmentStore{
+			record: db.ClearanceRecord{ID: 3, StudentID: 7, Status: status},
+			attachments: map[int64]db.RecordAttachment{
+				10: {ID: 10, RecordID: 3, StorageKey: "records/3/a", UploadedByRole: "student", UploadedByID: 7, Source: db.AttachmentSourceRecord},
+				11: {ID: 11, RecordID: 3, StorageKey: "records/3/b", UploadedByRole: "student", UploadedByID: 7, Source: db.AttachmentSourceRecord},
+			},
+		}
+	}
+
+	// a record under review keeps at least one file
+	store := newStore(db.RecordStatusInReview)
+	server := newTestServer(t, util.Config{}, store)
+	require.Equal(t, http.StatusOK, doAuthorized(t, server, "student", 7, http.MethodDelete, "/clearance_records/3/attachments/10"))
+	require.Equal(t, http.StatusConflict, doAuthorized(t, server, "student", 7, http.MethodDelete, "/clearance_records/3/attachments/11"))
+	require.Len(t, store.attachments, 1)
+
+	// files sent with comments never count, so they may always go
+	store.attachments[12] = db.RecordAttachment{ID: 12, RecordID: 3, StorageKey: "records/3/c", UploadedByRole: "student", UploadedByID: 7, Source: db.AttachmentSourceComment}
+	require.Equal(t, http.StatusOK, doAuthorized(t, server, "student", 7, http.MethodDelete, "/clearance_records/3/attachments/12"))
+	require.Equal(t, http.StatusConflict, doAuthorized(t, server, "student", 7, http.MethodDelete, "/clearance_records/3/attachments/11"))
+
+	// once the record is back with the student the file may go
+	for _, status := range []string{db.RecordStatusPending, db.RecordStatusRejected} {
+		store := newStore(status)
+		server := newTestServer(t, util.Config{}, store)
+		require.Equal(t, http.StatusOK, doAuthorized(t, server, "student", 7, http.MethodDelete, "/clearance_records/3/attachments/10"), status)
+		require.Equal(t, http.StatusOK, doAuthorized(t, server, "student", 7, http.MethodDelete, "/clearance_records/3/attachments/11"), status)
+		require.Empty(t, store.attachments, status)
+	}
+}
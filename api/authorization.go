@@ -0,0 +1,152 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+
+	db "github.com/backendn/clearance_system/db/sqlc"
+	middleware "github.com/backendn/clearance_system/middlware"
+	"github.com/backendn/clearance_system/token"
+	"github.com/gin-gonic/gin"
+)
+
+// authPayload returns the token payload of the authenticated caller.
+func authPayload(ctx *gin.Context) *token.Payload {
+	return middleware.GetPayload(ctx)
+}
+
+// forbidden aborts the request with the shared 403 body.
+func forbidden(ctx *gin.Context) {
+	ctx.AbortWithStatusJSON(http.StatusForbidden, middleware.ErrForbidden)
+}
+
+// canViewRecord reports whether the caller may read a clearance record.
+// Admins see everything, students see their own records and staff see
+// records for items they approve or sign, that belong to their department or
+// their approver pool, that are assigned to them or that were delegated to
+// them.
+func (server *Server) canViewRecord(ctx *gin.Context, payload *token.Payload, record db.ClearanceRecord) (bool, error) {
+	switch payload.Role {
+	case "admin":
+		return true, nil
+	case "student":
+		return record.StudentID == payload.UserID, nil
+	}
+
+	staff, err := server.store.GetStaffUser(ctx, payload.UserID)
+	if err != nil {
+		return false, err
+	}
+
+	item, err := server.store.GetRecordItem(ctx, record.ID)
+	if err != nil {
+		return false, err
+	}
+
+	if item.ApproverStaffID == staff.ID || item.DepartmentID == staff.DepartmentID ||
+		(item.AssignedStaffID.Valid && item.AssignedStaffID.Int64 == staff.ID) {
+		return true, nil
+	}
+
+	if item.ApproverPool == db.ApproverPoolGroup {
+		member, err := server.store.IsItemPoolMember(ctx, db.IsItemPoolMemberParams{
+			StaffID: staff.ID,
+			ItemID:  item.ItemID,
+		})
+		if err != nil || member {
+			return member, err
+		}
+	}
+
+	if item.RequiredSignatures > 1 {
+		signer, err := server.store.IsItemSigner(ctx, db.IsItemSignerParams{
+			StaffID: staff.ID,
+			ItemID:  item.ItemID,
+		})
+		if err != nil || signer {
+			return signer, err
+		}
+	}
+
+	responsible, ok := db.ResponsibleApprover(item)
+	if !ok {
+		return false, nil
+	}
+
+	return server.store.HasActiveDelegation(ctx, db.HasActiveDelegationParams{
+		DelegatorStaffID:  responsible,
+		SubstituteStaffID: staff.ID,
+	})
+}
+
+// canDecideRecord reports whether the caller may change the status of a
+// clearance record. Only the record's responsible approver (the item's
+// approver, or the assignee for pooled items), a substitute they delegated
+// to, or an admin may decide; items that require several signatures are
+// decided by their signers. For a substitute it also returns the approver
+// they act on behalf of.
+func (server *Server) canDecideRecord(ctx *gin.Context, payload *token.Payload, record db.ClearanceRecord) (bool, sql.NullInt64, error) {
+	if payload.Role == "admin" {
+		return true, sql.NullInt64{}, nil
+	}
+	if payload.Role == "student" {
+		return false, sql.NullInt64{}, nil
+	}
+
+	item, err := server.store.GetRecordItem(ctx, record.ID)
+	if err != nil {
+		return false, sql.NullInt64{}, err
+	}
+
+	// multi-signature items are decided by their signers
+	if item.RequiredSignatures > 1 {
+		signer, err := server.store.IsItemSigner(ctx, db.IsItemSignerParams{
+			StaffID: payload.UserID,
+			ItemID:  item.ItemID,
+		})
+		return signer, sql.NullInt64{}, err
+	}
+
+	// pooled records have to be claimed or assigned first
+	responsible, ok := db.ResponsibleApprover(item)
+	if !ok {
+		return false, sql.NullInt64{}, nil
+	}
+
+	if responsible == payload.UserID {
+		return true, sql.NullInt64{}, nil
+	}
+
+	delegated, err := server.store.HasActiveDelegation(ctx, db.HasActiveDelegationParams{
+		DelegatorStaffID:  responsible,
+		SubstituteStaffID: payload.UserID,
+	})
+	if err != nil || !delegated {
+		return false, sql.NullInt64{}, err
+	}
+
+	return true, ToNullInt64(responsible), nil
+}
+
+// canSuperviseRecord reports whether the caller may reassign a pooled
+// record: admins, the item's approver and supervisors of the item's group.
+func (server *Server) canSuperviseRecord(ctx *gin.Context, payload *token.Payload, item db.GetRecordItemRow) (bool, error) {
+	if payload.Role == "admin" {
+		return true, nil
+	}
+	if payload.Role != "staff" {
+		return false, nil
+	}
+
+	if item.ApproverStaffID == payload.UserID {
+		return true, nil
+	}
+	if item.ApproverPool != db.ApproverPoolGroup {
+		return false, nil
+	}
+
+	return server.store.IsApproverGroupSupervisor(ctx, db.IsApproverGroupSupervisorParams{
+		GroupID: item.ApproverGroupID.Int64,
+		StaffID: payload.UserID,
+	})
+}
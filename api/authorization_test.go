@@ -0,0 +1,78 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	db "github.com/backendn/clearance_system/db/sqlc"
+	"github.com/backendn/clearance_system/util"
+	"github.com/stretchr/testify/require"
+)
+
+// notificationStore serves a single notification; every other store call
+// panics, which shows up as a 500.
+type notificationStore struct {
+	db.Store
+	notification db.Notification
+}
+
+func (s notificationStore) GetNotification(_ context.Context, id int64) (db.Notification, error) {
+	if id != s.notification.ID {
+		return db.Notification{}, sql.ErrNoRows
+	}
+	return s.notification, nil
+}
+
+func doAuthorized(t *testing.T, server *Server, role string, userID int64, method, path string) int {
+	accessToken, _, err := server.tokenMaker.CreateToken(userID, role, time.Minute)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(method, path, nil)
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	recorder := httptest.NewRecorder()
+	server.router.ServeHTTP(recorder, req)
+	return recorder.Code
+}
+
+func TestStudentCannotReachStaffRoutes(t *testing.T) {
+	server := newTestServer(t, util.Config{}, nil)
+
+	routes := []struct{ method, path string }{
+		{http.MethodPatch, "/clearance_items/1"},
+		{http.MethodDelete, "/clearance_items/1"},
+		{http.MethodGet, "/students/number/S0001"},
+		{http.MethodPatch, "/departments/1"},
+		{http.MethodPatch, "/staff_users/1"},
+		{http.MethodPost, "/notifications"},
+	}
+	for _, route := range routes {
+		code := doAuthorized(t, server, "student", 7, route.method, route.path)
+		require.Equal(t, http.StatusForbidden, code, "%s %s", route.method, route.path)
+	}
+}
+
+func TestNotificationsAreOnlyReachableByTheirRecipient(t *testing.T) {
+	store := notificationStore{notification: db.Notification{
+		ID:                 5,
+		RecipientStudentID: sql.NullInt64{Int64: 7, Valid: true},
+		Message:            "hello",
+	}}
+	server := newTestServer(t, util.Config{}, store)
+
+	for _, method := range []string{http.MethodGet, http.MethodPatch, http.MethodDelete} {
+		path := "/notifications/5"
+		if method == http.MethodPatch {
+			path += "/read"
+		}
+		require.Equal(t, http.StatusForbidden, doAuthorized(t, server, "student", 8, method, path), method)
+		require.Equal(t, http.StatusForbidden, doAuthorized(t, server, "staff", 7, method, path), method)
+	}
+
+	require.Equal(t, http.StatusOK, doAuthorized(t, server, "student", 7, http.MethodGet, "/notifications/5"))
+	require.Equal(t, http.StatusOK, doAuthorized(t, server, "admin", 1, http.MethodGet, "/notifications/5"))
+	require.Equal(t, http.StatusNotFound, doAuthorized(t, server, "student", 7, http.MethodGet, "/notifications/6"))
+}
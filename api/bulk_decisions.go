@@ -0,0 +1,116 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BulkUpdateClearanceRecordStatusRequest struct {
+	// at most 500 records per request
+	RecordIDs []int64 `json:"record_ids" binding:"required,min=1,max=500,dive,min=1"`
+	Status    string  `json:"status" binding:"required,oneof=approved rejected"`
+	Note      string  `json:"note"`
+	HandledBy int64   `json:"handled_by"` // only used by admins; staff always act as themselves
+}
+
+type bulkRecordResult struct {
+	RecordID int64  `json:"record_id"`
+	OK       bool   `json:"ok"`
+	Status   string `json:"status,omitempty"`
+	Code     string `json:"code,omitempty"`
+	Error    string `json:"error,omitempty"`
+	// AwaitingSignatures is set when the decision was stored as a signature
+	// and the item needs more of them.
+	AwaitingSignatures bool `json:"awaiting_signatures,omitempty"`
+}
+
+// POST /clearance_records/bulk_status
+// Applies one decision to many records. Every record goes through the same
+// checks as PATCH /clearance_records/:id/status and is decided in its own
+// short transaction, so a failure on one record leaves the others intact
+// and no lock is held for the whole batch.
+func (server *Server) bulkUpdateClearanceRecordStatus(ctx *gin.Context) {
+	var req BulkUpdateClearanceRecordStatusRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	decision := UpdateClearanceRecordStatusRequest{
+		Status:    req.Status,
+		Note:      req.Note,
+		HandledBy: req.HandledBy,
+	}
+
+	seen := make(map[int64]bool, len(req.RecordIDs))
+	results := make([]bulkRecordResult, 0, len(req.RecordIDs))
+	succeeded := 0
+
+	for _, id := range req.RecordIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		// stop early if the client went away
+		if err := ctx.Request.Context().Err(); err != nil {
+			results = append(results, bulkRecordResult{RecordID: id, Code: "canceled", Error: err.Error()})
+			continue
+		}
+
+		result, derr := server.decideRecord(ctx, id, decision)
+		if derr != nil {
+			r := bulkRecordResult{RecordID: id}
+			r.Error, _ = derr.body["error"].(string)
+			r.Code, _ = derr.body["code"].(string)
+			if r.Code == "" {
+				r.Code = statusCode(derr.status)
+			}
+			results = append(results, r)
+			continue
+		}
+
+		succeeded++
+		results = append(results, bulkRecordResult{
+			RecordID:           id,
+			OK:                 true,
+			Status:             result.Record.Status,
+			AwaitingSignatures: result.AwaitingSignatures,
+		})
+	}
+
+	// records still waiting for signatures are returned unchanged, so the
+	// recipient comes from the caller rather than from the records
+	handledBy := req.HandledBy
+	if payload := authPayload(ctx); payload.Role != "admin" {
+		handledBy = payload.UserID
+	}
+	if succeeded > 0 {
+		server.sendNotification(ctx, handledBy, 0,
+			fmt.Sprintf("You updated %d clearance records with status '%s'.", succeeded, req.Status))
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status":    req.Status,
+		"succeeded": succeeded,
+		"failed":    len(results) - succeeded,
+		"results":   results,
+	})
+}
+
+// statusCode names the HTTP status of a failed record for errors that don't
+// carry their own code.
+func statusCode(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return "bad_request"
+	case http.StatusForbidden:
+		return "forbidden"
+	case http.StatusNotFound:
+		return "not_found"
+	default:
+		return "internal_error"
+	}
+}
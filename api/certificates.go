@@ -0,0 +1,284 @@
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/backendn/clearance_system/certificate"
+	db "github.com/backendn/clearance_system/db/sqlc"
+	"github.com/backendn/clearance_system/storage"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+var errRequestNotCleared = errors.New("the clearance request is not fully cleared yet")
+
+// issueCertificate renders, signs and stores the certificate of a cleared
+// request. A request gets a single certificate; when another call issued it
+// first, that certificate is returned.
+func (server *Server) issueCertificate(ctx *gin.Context, request db.ClearanceRequest) (db.ClearanceCertificate, error) {
+	if request.Status != db.RequestStatusCleared {
+		return db.ClearanceCertificate{}, errRequestNotCleared
+	}
+
+	student, err := server.store.GetStudent(ctx, request.StudentID)
+	if err != nil {
+		return db.ClearanceCertificate{}, err
+	}
+	session, err := server.store.GetSession(ctx, request.SessionID)
+	if err != nil {
+		return db.ClearanceCertificate{}, err
+	}
+	clearanceType, err := server.store.GetClearanceType(ctx, request.ClearanceTypeID)
+	if err != nil {
+		return db.ClearanceCertificate{}, err
+	}
+	rows, err := server.store.ListCertificateItems(ctx, sql.NullInt64{Int64: request.ID, Valid: true})
+	if err != nil {
+		return db.ClearanceCertificate{}, err
+	}
+
+	// a request cleared again after a revocation gets a numbered reissue
+	previous, err := server.store.CountRequestCertificates(ctx, request.ID)
+	if err != nil {
+		return db.ClearanceCertificate{}, err
+	}
+	code, err := certificate.NewVerificationCode()
+	if err != nil {
+		return db.ClearanceCertificate{}, err
+	}
+
+	issuedAt := time.Now().UTC().Truncate(time.Second)
+	number := fmt.Sprintf("CLR-%d-%06d", issuedAt.Year(), request.ID)
+	if previous > 0 {
+		number += fmt.Sprintf("-%d", previous+1)
+	}
+	cert := certificate.Certificate{
+		Number:           number,
+		StudentName:      student.FirstName + " " + student.LastName,
+		StudentNumber:    student.StudentNumber,
+		Session:          session.Name,
+		ClearanceType:    clearanceType.Name,
+		ClearedAt:        request.CompletedAt.Time,
+		IssuedAt:         issuedAt,
+		Items:            make([]certificate.Item, 0, len(rows)),
+		VerificationCode: code,
+	}
+	for _, row := range rows {
+		cert.Items = append(cert.Items, certificate.Item{
+			Title:      row.Title,
+			Department: row.DepartmentName,
+			Status:     row.Status,
+			Approver:   row.ApproverName,
+			ApprovedAt: row.HandledAt,
+		})
+	}
+
+	payload, err := certificate.Payload(cert)
+	if err != nil {
+		return db.ClearanceCertificate{}, err
+	}
+	signature := server.signer.Sign(payload)
+
+	pdf, err := certificate.Render(cert, signature, server.signer.KeyID(), server.verifyURL(code))
+	if err != nil {
+		return db.ClearanceCertificate{}, err
+	}
+
+	key := fmt.Sprintf("certificates/%d/%s.pdf", request.ID, uuid.NewString())
+	if err := server.storage.Put(ctx, key, bytes.NewReader(pdf), int64(len(pdf)), "application/pdf"); err != nil {
+		return db.ClearanceCertificate{}, err
+	}
+
+	checksum := sha256.Sum256(pdf)
+	issued, err := server.store.CreateClearanceCertificate(ctx, db.CreateClearanceCertificateParams{
+		RequestID:         request.ID,
+		StudentID:         request.StudentID,
+		CertificateNumber: cert.Number,
+		StorageKey:        key,
+		SizeBytes:         int64(len(pdf)),
+		ChecksumSha256:    hex.EncodeToString(checksum[:]),
+		Payload:           string(payload),
+		Signature:         base64.StdEncoding.EncodeToString(signature),
+		KeyID:             server.signer.KeyID(),
+		IssuedAt:          issuedAt,
+		VerificationCode:  code,
+	})
+	if err != nil {
+		// the file is ours alone; drop it whatever went wrong
+		if delErr := server.storage.Delete(ctx, key); delErr != nil {
+			ctx.Error(delErr)
+		}
+
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return server.store.GetCertificateByRequest(ctx, request.ID)
+		}
+		return db.ClearanceCertificate{}, err
+	}
+
+	return issued, nil
+}
+
+// GET /clearance_requests/:id/certificate
+// Downloads the signed certificate of a cleared request. Only the student
+// and admins may download it. Certificates that could not be issued when
+// the request was cleared are issued on the first download.
+func (server *Server) downloadCertificate(ctx *gin.Context) {
+	id, err := getIDParam(ctx)
+	if err != nil {
+		return
+	}
+
+	request, err := server.store.GetClearanceRequest(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorMessage("clearance request not found"))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	payload := authPayload(ctx)
+	if payload.Role != "admin" && (payload.Role != "student" || request.StudentID != payload.UserID) {
+		forbidden(ctx)
+		return
+	}
+
+	cert, err := server.store.GetCertificateByRequest(ctx, request.ID)
+	if err == sql.ErrNoRows {
+		cert, err = server.issueCertificate(ctx, request)
+	}
+	if err != nil {
+		if errors.Is(err, errRequestNotCleared) {
+			ctx.JSON(http.StatusConflict, errorCode("request_not_cleared", err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	file, err := server.storage.Get(ctx, cert.StorageKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, errorMessage("certificate content is missing"))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorMessage("failed to read certificate"))
+		return
+	}
+	defer file.Close()
+
+	ctx.DataFromReader(http.StatusOK, cert.SizeBytes, "application/pdf", file, map[string]string{
+		"Content-Disposition":     mime.FormatMediaType("attachment", map[string]string{"filename": cert.CertificateNumber + ".pdf"}),
+		"X-Content-Type-Options":  "nosniff",
+		"ETag":                    `"` + cert.ChecksumSha256 + `"`,
+		"X-Checksum-Sha256":       cert.ChecksumSha256,
+		"X-Certificate-Signature": cert.Signature,
+		"X-Certificate-Key-Id":    cert.KeyID,
+	})
+}
+
+// GET /certificates/public_key
+// Publishes the key that verifies certificate signatures.
+func (server *Server) getCertificatePublicKey(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, gin.H{
+		"algorithm":  "Ed25519",
+		"key_id":     server.signer.KeyID(),
+		"public_key": base64.StdEncoding.EncodeToString(server.signer.PublicKey()),
+	})
+}
+
+// verifyURL returns the public address a certificate's code is checked at,
+// or nothing when CERTIFICATE_VERIFY_URL is not configured.
+func (server *Server) verifyURL(code string) string {
+	if server.config.CertificateVerifyURL == "" {
+		return ""
+	}
+	return strings.TrimRight(server.config.CertificateVerifyURL, "/") + "/" + code
+}
+
+// Signature states reported by GET /verify/:code.
+const (
+	signatureValid      = "valid"
+	signatureInvalid    = "invalid"
+	signatureUnknownKey = "unknown_key"
+)
+
+// GET /verify/:code
+// Lets anyone holding a certificate check that it is genuine. It is not
+// authenticated, so it only returns the certificate's headline fields, the
+// signature status and whether the certificate was revoked.
+func (server *Server) verifyCertificate(ctx *gin.Context) {
+	ctx.Header("Cache-Control", "no-store")
+
+	code := certificate.NormalizeVerificationCode(ctx.Param("code"))
+	if len(code) > 32 {
+		ctx.JSON(http.StatusNotFound, errorMessage("certificate not found"))
+		return
+	}
+
+	cert, err := server.store.GetCertificateByVerificationCode(ctx, code)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorMessage("certificate not found"))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// the stored payload is what was signed; it is checked again rather
+	// than trusting the row
+	var signed certificate.Certificate
+	if err := json.Unmarshal([]byte(cert.Payload), &signed); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorMessage("certificate payload is unreadable"))
+		return
+	}
+	signatureStatus := signatureUnknownKey
+	if cert.KeyID == server.signer.KeyID() {
+		signatureStatus = signatureInvalid
+		signature, err := base64.StdEncoding.DecodeString(cert.Signature)
+		if err == nil && certificate.Verify(server.signer.PublicKey(), []byte(cert.Payload), signature) {
+			signatureStatus = signatureValid
+		}
+	}
+
+	status := "valid"
+	switch {
+	case cert.RevokedAt.Valid:
+		status = "revoked"
+	case signatureStatus != signatureValid:
+		status = "invalid"
+	}
+
+	response := gin.H{
+		"status":             status,
+		"signature":          signatureStatus,
+		"key_id":             cert.KeyID,
+		"certificate_number": signed.Number,
+		"student_name":       signed.StudentName,
+		"clearance_type":     signed.ClearanceType,
+		"session":            signed.Session,
+		"cleared_at":         signed.ClearedAt,
+		"issued_at":          signed.IssuedAt,
+	}
+	if cert.RevokedAt.Valid {
+		response["revoked_at"] = cert.RevokedAt.Time
+		response["revocation_reason"] = cert.RevocationReason
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,125 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+
+	db "github.com/backendn/clearance_system/db/sqlc"
+	"github.com/gin-gonic/gin"
+)
+
+type createClearanceItemRuleRequest struct {
+	RuleType          string `json:"rule_type" binding:"required"`
+	DepartmentID      int64  `json:"department_id" binding:"omitempty,min=1"`
+	MinEnrollmentYear int32  `json:"min_enrollment_year" binding:"omitempty,min=1"`
+	MaxEnrollmentYear int32  `json:"max_enrollment_year" binding:"omitempty,min=1"`
+	StudentID         int64  `json:"student_id" binding:"omitempty,min=1"`
+}
+
+// POST /admins/clearance_items/:id/rules
+func (s *Server) createClearanceItemRule(ctx *gin.Context) {
+	itemID, err := getIDParam(ctx)
+	if err != nil {
+		return
+	}
+
+	var req createClearanceItemRuleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if _, err := s.store.GetClearanceItem(ctx, itemID); err != nil {
+		ctx.JSON(http.StatusNotFound, errorMessage("clearance item not found"))
+		return
+	}
+
+	arg := db.CreateClearanceItemRuleParams{
+		ClearanceItemID: itemID,
+		RuleType:        req.RuleType,
+	}
+
+	// Each rule type uses its own fields; the others are ignored.
+	switch req.RuleType {
+	case db.RuleTypeDepartment:
+		if _, err := s.store.GetDepartment(ctx, req.DepartmentID); err != nil {
+			ctx.JSON(http.StatusBadRequest, errorMessage("invalid department ID"))
+			return
+		}
+		arg.DepartmentID = ToNullInt64(req.DepartmentID)
+
+	case db.RuleTypeEnrollmentYear:
+		if req.MinEnrollmentYear == 0 && req.MaxEnrollmentYear == 0 {
+			ctx.JSON(http.StatusBadRequest, errorMessage("min_enrollment_year or max_enrollment_year is required"))
+			return
+		}
+		if req.MinEnrollmentYear != 0 && req.MaxEnrollmentYear != 0 && req.MinEnrollmentYear > req.MaxEnrollmentYear {
+			ctx.JSON(http.StatusBadRequest, errorMessage("min_enrollment_year must not be after max_enrollment_year"))
+			return
+		}
+		arg.MinEnrollmentYear = sql.NullInt32{Int32: req.MinEnrollmentYear, Valid: req.MinEnrollmentYear != 0}
+		arg.MaxEnrollmentYear = sql.NullInt32{Int32: req.MaxEnrollmentYear, Valid: req.MaxEnrollmentYear != 0}
+
+	case db.RuleTypeIncludeStudent, db.RuleTypeExcludeStudent:
+		if _, err := s.store.GetStudent(ctx, req.StudentID); err != nil {
+			ctx.JSON(http.StatusBadRequest, errorMessage("invalid student ID"))
+			return
+		}
+		arg.StudentID = ToNullInt64(req.StudentID)
+
+	default:
+		ctx.JSON(http.StatusBadRequest, errorMessage(
+			"rule_type must be one of department, enrollment_year, include_student, exclude_student"))
+		return
+	}
+
+	rule, err := s.store.CreateClearanceItemRule(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, rule)
+}
+
+// GET /admins/clearance_items/:id/rules
+func (s *Server) listClearanceItemRules(ctx *gin.Context) {
+	itemID, err := getIDParam(ctx)
+	if err != nil {
+		return
+	}
+
+	rules, err := s.store.ListRulesByItem(ctx, itemID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rules)
+}
+
+// DELETE /admins/clearance_items/:id/rules/:rule_id
+func (s *Server) deleteClearanceItemRule(ctx *gin.Context) {
+	itemID, err := getIDParam(ctx)
+	if err != nil {
+		return
+	}
+
+	ruleID, err := strconv.ParseInt(ctx.Param("rule_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorMessage("invalid rule_id parameter"))
+		return
+	}
+
+	err = s.store.DeleteClearanceItemRule(ctx, db.DeleteClearanceItemRuleParams{
+		ID:              ruleID,
+		ClearanceItemID: itemID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "deleted"})
+}
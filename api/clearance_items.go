@@ -15,6 +15,11 @@ type createClearanceItemRequest struct {
 	ApproverStaffID    int64  `json:"approver_staff_id" binding:"required,min=1"`
 	RequiresAttachment bool   `json:"requires_attachment"`
 	Sequence           int32  `json:"sequence" binding:"required,min=1"`
+	EnforceSequence    bool   `json:"enforce_sequence"`
+	// Optional; items without a type apply to every clearance type.
+	ClearanceTypeID int64 `json:"clearance_type_id" binding:"omitempty,min=1"`
+	// Items that must be approved or waived before this one.
+	PrerequisiteIDs []int64 `json:"prerequisite_ids" binding:"omitempty,dive,min=1"`
 }
 
 // POST /clearance-items
@@ -39,6 +44,17 @@ func (s *Server) createClearanceItem(ctx *gin.Context) {
 		return
 	}
 
+	if req.ClearanceTypeID != 0 {
+		if _, err := s.store.GetClearanceType(ctx, req.ClearanceTypeID); err != nil {
+			ctx.JSON(http.StatusBadRequest, errorMessage("invalid clearance type ID"))
+			return
+		}
+	}
+
+	if !s.validPrerequisites(ctx, 0, req.PrerequisiteIDs) {
+		return
+	}
+
 	arg := db.CreateClearanceItemParams{
 		Code:               req.Code,
 		Title:              req.Title,
@@ -47,10 +63,25 @@ func (s *Server) createClearanceItem(ctx *gin.Context) {
 		ApproverStaffID:    req.ApproverStaffID,
 		RequiresAttachment: req.RequiresAttachment,
 		Sequence:           req.Sequence,
-	}
-
-	item, err := s.store.CreateClearanceItem(ctx, arg)
+		EnforceSequence:    req.EnforceSequence,
+		ClearanceTypeID:    ToNullInt64(req.ClearanceTypeID),
+	}
+
+	var item db.ClearanceItem
+	err = s.store.ExecTx(ctx, func(q db.Querier) error {
+		var err error
+		item, err = q.CreateClearanceItem(ctx, arg)
+		if err != nil || len(req.PrerequisiteIDs) == 0 {
+			return err
+		}
+		_, err = db.ReplaceItemPrerequisites(ctx, q, item.ID, req.PrerequisiteIDs)
+		return err
+	})
 	if err != nil {
+		if isItemGraphError(err) {
+			ctx.JSON(http.StatusBadRequest, errorCode("invalid_item_graph", err))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
@@ -118,6 +149,11 @@ type updateClearanceItemRequest struct {
 	ApproverStaffID    int64  `json:"approver_staff_id" binding:"required"`
 	RequiresAttachment bool   `json:"requires_attachment"`
 	Sequence           int32  `json:"sequence" binding:"required,min=1"`
+	EnforceSequence    bool   `json:"enforce_sequence"`
+	// Optional; items without a type apply to every clearance type.
+	ClearanceTypeID int64 `json:"clearance_type_id" binding:"omitempty,min=1"`
+	// Replaces the item's prerequisites when present; omit it to keep them.
+	PrerequisiteIDs []int64 `json:"prerequisite_ids" binding:"omitempty,dive,min=1"`
 }
 
 // PUT /clearance-items/:id
@@ -145,6 +181,17 @@ func (s *Server) updateClearanceItem(ctx *gin.Context) {
 		return
 	}
 
+	if req.ClearanceTypeID != 0 {
+		if _, err := s.store.GetClearanceType(ctx, req.ClearanceTypeID); err != nil {
+			ctx.JSON(http.StatusBadRequest, errorMessage("invalid clearance type ID"))
+			return
+		}
+	}
+
+	if !s.validPrerequisites(ctx, id, req.PrerequisiteIDs) {
+		return
+	}
+
 	arg := db.UpdateClearanceItemParams{
 		Code:               req.Code,
 		Title:              req.Title,
@@ -153,11 +200,31 @@ func (s *Server) updateClearanceItem(ctx *gin.Context) {
 		ApproverStaffID:    req.ApproverStaffID,
 		RequiresAttachment: req.RequiresAttachment,
 		Sequence:           req.Sequence,
+		EnforceSequence:    req.EnforceSequence,
+		ClearanceTypeID:    ToNullInt64(req.ClearanceTypeID),
 		ID:                 id,
 	}
 
-	item, err := s.store.UpdateClearanceItem(ctx, arg)
+	// a new clearance type can break links to and from the item, so the
+	// graph is checked even when the prerequisites stay the same
+	var item db.ClearanceItem
+	err = s.store.ExecTx(ctx, func(q db.Querier) error {
+		var err error
+		item, err = q.UpdateClearanceItem(ctx, arg)
+		if err != nil {
+			return err
+		}
+		if req.PrerequisiteIDs == nil {
+			return db.CheckItemGraph(ctx, q)
+		}
+		_, err = db.ReplaceItemPrerequisites(ctx, q, id, req.PrerequisiteIDs)
+		return err
+	})
 	if err != nil {
+		if isItemGraphError(err) {
+			ctx.JSON(http.StatusBadRequest, errorCode("invalid_item_graph", err))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
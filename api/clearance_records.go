@@ -2,12 +2,15 @@ package api
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
-	"time"
+	"strings"
 
 	db "github.com/backendn/clearance_system/db/sqlc"
+	middleware "github.com/backendn/clearance_system/middlware"
 	"github.com/gin-gonic/gin"
 )
 
@@ -19,11 +22,16 @@ type CreateClearanceRecordRequest struct {
 	AttachmentURL   string `json:"attachment_url"`
 }
 
+type SubmitClearanceRecordRequest struct {
+	Note string `json:"note"`
+}
+
 type UpdateClearanceRecordStatusRequest struct {
 	Status        string `json:"status" binding:"required"`
 	Note          string `json:"note"`
-	HandledBy     int64  `json:"handled_by" binding:"required"`
+	HandledBy     int64  `json:"handled_by"` // only used by admins; staff always act as themselves
 	AttachmentURL string `json:"attachment_url"`
+	Override      bool   `json:"override"` // admins only: bypass the status transition rules
 }
 
 func (server *Server) createClearanceRecord(ctx *gin.Context) {
@@ -37,13 +45,38 @@ func (server *Server) createClearanceRecord(ctx *gin.Context) {
 		StudentID:       req.StudentID,
 		ClearanceItemID: req.ClearanceItemID,
 		SessionID:       req.SessionID,
-		Status:          "pending",
+		Status:          db.RecordStatusPending,
 		Note:            req.Note,
-		HandledBy:       0,
 		AttachmentUrl:   NullableString(req.AttachmentURL),
 	}
 
-	record, err := server.store.CreateClearanceRecord(ctx, arg)
+	payload := authPayload(ctx)
+	var record db.ClearanceRecord
+	err := server.store.ExecTx(ctx, func(q db.Querier) error {
+		var err error
+		record, err = q.CreateClearanceRecord(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		item, err := q.GetClearanceItem(ctx, record.ClearanceItemID)
+		if err != nil {
+			return err
+		}
+		record, err = db.AutoAssignRecord(ctx, q, item, record)
+		if err != nil {
+			return err
+		}
+
+		_, err = q.CreateClearanceRecordEvent(ctx, db.CreateClearanceRecordEventParams{
+			RecordID:  record.ID,
+			ActorRole: payload.Role,
+			ActorID:   payload.UserID,
+			NewStatus: record.Status,
+			Note:      record.Note,
+		})
+		return err
+	})
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
@@ -68,6 +101,16 @@ func (server *Server) getClearanceRecord(ctx *gin.Context) {
 		return
 	}
 
+	allowed, err := server.canViewRecord(ctx, authPayload(ctx), record)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if !allowed {
+		forbidden(ctx)
+		return
+	}
+
 	ctx.JSON(http.StatusOK, record)
 }
 func (server *Server) listRecordsByStudent(ctx *gin.Context) {
@@ -77,7 +120,23 @@ func (server *Server) listRecordsByStudent(ctx *gin.Context) {
 		return
 	}
 
-	records, err := server.store.ListRecordsByStudent(ctx, studentID)
+	var records []db.ClearanceRecord
+	payload := authPayload(ctx)
+	if payload.Role == "staff" {
+		var staff db.StaffUser
+		staff, err = server.store.GetStaffUser(ctx, payload.UserID)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		records, err = server.store.ListRecordsByStudentForStaff(ctx, db.ListRecordsByStudentForStaffParams{
+			StudentID:       studentID,
+			ApproverStaffID: staff.ID,
+			DepartmentID:    staff.DepartmentID,
+		})
+	} else {
+		records, err = server.store.ListRecordsByStudent(ctx, studentID)
+	}
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
@@ -92,7 +151,23 @@ func (server *Server) listRecordsBySession(ctx *gin.Context) {
 		return
 	}
 
-	records, err := server.store.ListRecordsBySession(ctx, sessionID)
+	var records []db.ClearanceRecord
+	payload := authPayload(ctx)
+	if payload.Role == "staff" {
+		var staff db.StaffUser
+		staff, err = server.store.GetStaffUser(ctx, payload.UserID)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		records, err = server.store.ListRecordsBySessionForStaff(ctx, db.ListRecordsBySessionForStaffParams{
+			SessionID:       sessionID,
+			ApproverStaffID: staff.ID,
+			DepartmentID:    staff.DepartmentID,
+		})
+	} else {
+		records, err = server.store.ListRecordsBySession(ctx, sessionID)
+	}
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
@@ -113,53 +188,398 @@ func (server *Server) updateClearanceRecordStatus(ctx *gin.Context) {
 		return
 	}
 
-	arg := db.UpdateClearanceRecordStatusParams{
+	result, derr := server.decideRecord(ctx, id, req)
+	if derr != nil {
+		ctx.JSON(derr.status, derr.body)
+		return
+	}
+	record := result.Record
+
+	// Notify staff for confirmation
+	if result.AwaitingSignatures {
+		server.sendNotification(ctx, result.Signature.StaffID, 0,
+			fmt.Sprintf("You signed clearance record %d; more signatures are needed.", record.ID))
+	} else {
+		server.sendNotification(ctx, record.HandledBy.Int64, 0,
+			fmt.Sprintf("You updated clearance record %d with status '%s'.", record.ID, record.Status))
+	}
+
+	ctx.JSON(http.StatusOK, record)
+}
+
+// decisionError is a refused decision on a record: the HTTP status and the
+// body the single-record endpoint answers with.
+type decisionError struct {
+	status int
+	body   gin.H
+}
+
+// decideRecord runs the permission and state checks for a status change by
+// the caller, applies it and notifies the student and any approvers whose
+// items were unlocked. It is shared by the single and the bulk endpoint.
+func (server *Server) decideRecord(ctx *gin.Context, id int64, req UpdateClearanceRecordStatusRequest) (db.UpdateClearanceRecordStatusTxResult, *decisionError) {
+	var none db.UpdateClearanceRecordStatusTxResult
+
+	if !db.IsValidRecordStatus(req.Status) {
+		return none, &decisionError{http.StatusBadRequest, errorCode("invalid_status",
+			fmt.Errorf("%w: %q", db.ErrInvalidRecordStatus, req.Status))}
+	}
+
+	current, err := server.store.GetClearanceRecord(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return none, &decisionError{http.StatusNotFound, errorMessage("clearance record not found")}
+		}
+		return none, &decisionError{http.StatusInternalServerError, errorResponse(err)}
+	}
+
+	payload := authPayload(ctx)
+	if req.Override && payload.Role != "admin" {
+		return none, &decisionError{http.StatusForbidden, middleware.ErrForbidden}
+	}
+
+	allowed, onBehalfOf, err := server.canDecideRecord(ctx, payload, current)
+	if err != nil {
+		return none, &decisionError{http.StatusInternalServerError, errorResponse(err)}
+	}
+	if !allowed {
+		return none, &decisionError{http.StatusForbidden, middleware.ErrForbidden}
+	}
+
+	handledBy := req.HandledBy
+	if payload.Role != "admin" {
+		handledBy = payload.UserID
+	}
+	if handledBy == 0 {
+		return none, &decisionError{http.StatusBadRequest, errorMessage("handled_by is required")}
+	}
+
+	arg := db.UpdateClearanceRecordStatusTxParams{
+		RecordID:      id,
 		Status:        req.Status,
 		Note:          req.Note,
-		HandledBy:     req.HandledBy,
-		HandledAt:     time.Now(),
+		HandledBy:     ToNullInt64(handledBy),
 		AttachmentUrl: NullableString(req.AttachmentURL),
-		ID:            id,
+		ActorRole:     payload.Role,
+		ActorID:       payload.UserID,
+		OnBehalfOf:    onBehalfOf,
+		Override:      req.Override,
+	}
+	if payload.Role == "staff" {
+		// only counts for items that require several signatures
+		arg.SignerStaffID = ToNullInt64(payload.UserID)
 	}
 
-	record, err := server.store.UpdateClearanceRecordStatus(ctx, arg)
+	result, err := server.store.UpdateClearanceRecordStatusTx(ctx, arg)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-		return
+		if errors.Is(err, db.ErrInvalidStatusTransition) {
+			return none, &decisionError{http.StatusConflict, errorCode("invalid_status_transition", err)}
+		}
+		if errors.Is(err, db.ErrItemNotActionable) {
+			return none, &decisionError{http.StatusConflict, errorCode("item_not_actionable", err)}
+		}
+		if errors.Is(err, db.ErrAttachmentRequired) {
+			return none, &decisionError{http.StatusUnprocessableEntity, attachmentRequiredBody(current, err)}
+		}
+		if errors.Is(err, db.ErrSignatureBlocked) {
+			return none, &decisionError{http.StatusConflict, errorCode("signature_blocked", err)}
+		}
+		if errors.Is(err, db.ErrSignatureDecisionOnly) {
+			return none, &decisionError{http.StatusConflict, errorCode("signature_decision_only", err)}
+		}
+		if errors.Is(err, db.ErrOpenObligations) {
+			return none, &decisionError{http.StatusConflict, errorCode("open_obligations", err)}
+		}
+		return none, &decisionError{http.StatusInternalServerError, errorResponse(err)}
 	}
-	// First load the clearance item
+	record := result.Record
+
+	// The update is committed; notification problems are only logged.
 	item, err := server.store.GetClearanceItem(ctx, record.ClearanceItemID)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-		return
+		ctx.Error(err)
+		return result, nil
+	}
+
+	if result.AwaitingSignatures {
+		server.sendNotification(ctx, 0, record.StudentID,
+			fmt.Sprintf("Your clearance item '%s' received an approval; more signatures are needed.", item.Title))
+		return result, nil
 	}
 
-	if arg.Status == "approved" {
+	if arg.Status == db.RecordStatusApproved {
 		server.sendNotification(ctx, 0, record.StudentID,
 			fmt.Sprintf("Your clearance item '%s' has been approved.", item.Title))
-	} else if arg.Status == "rejected" {
+	} else if arg.Status == db.RecordStatusRejected {
 		server.sendNotification(ctx, 0, record.StudentID,
 			fmt.Sprintf("Your clearance item '%s' has been rejected. Note: %s", item.Title, arg.Note))
 	}
 
-	// Notify staff for confirmation
-	server.sendNotification(ctx, record.HandledBy, 0,
-		fmt.Sprintf("You updated clearance record %d with status '%s'.", record.ID, arg.Status))
+	if len(result.Unlocked) > 0 {
+		student, err := server.store.GetStudent(ctx, record.StudentID)
+		if err != nil {
+			ctx.Error(err)
+			return result, nil
+		}
+		fullName := student.FirstName + " " + student.LastName
 
-	ctx.JSON(http.StatusOK, record)
+		for _, next := range result.Unlocked {
+			server.sendNotification(ctx, approverToNotify(next.ApproverStaffID, next.AssignedStaffID), 0,
+				"New clearance request pending: "+fullName+" - Item: "+next.Title)
+		}
+	}
+
+	if result.Cleared {
+		server.sendNotification(ctx, 0, record.StudentID,
+			"Congratulations! Your clearance request has been fully cleared.")
+
+		// a failure here is retried when the certificate is first downloaded
+		if _, err := server.issueCertificate(ctx, result.Request); err != nil {
+			ctx.Error(err)
+		} else {
+			server.sendNotification(ctx, 0, record.StudentID,
+				"Your clearance certificate is ready to download.")
+		}
+	}
+
+	for _, revoked := range result.RevokedCertificates {
+		server.sendNotification(ctx, 0, revoked.StudentID,
+			fmt.Sprintf("Your clearance certificate %s was revoked because %s.", revoked.CertificateNumber, revoked.RevocationReason))
+	}
+
+	return result, nil
 }
-func (server *Server) deleteClearanceRecord(ctx *gin.Context) {
-	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+
+// GET /clearance_records/:id/history
+func (server *Server) getClearanceRecordHistory(ctx *gin.Context) {
+	record, ok := server.viewableRecord(ctx)
+	if !ok {
+		return
+	}
+
+	events, err := server.store.ListClearanceRecordEvents(ctx, record.ID)
 	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"record": record,
+		"events": events,
+	})
+}
+
+// attachmentRequired writes the validation error returned when a record is
+// sent for review or approved without its required attachment.
+func attachmentRequired(ctx *gin.Context, record db.ClearanceRecord, err error) {
+	ctx.JSON(http.StatusUnprocessableEntity, attachmentRequiredBody(record, err))
+}
+
+func attachmentRequiredBody(record db.ClearanceRecord, err error) gin.H {
+	return validationError("attachment_required", err, gin.H{
+		"record_id":         record.ID,
+		"clearance_item_id": record.ClearanceItemID,
+		"field":             "attachment",
+		"upload_url":        fmt.Sprintf("/clearance_records/%d/attachments", record.ID),
+	})
+}
+
+// POST /clearance_records/:id/submit
+// The student marks their record as ready for review. Rejected records go
+// through resubmitClearanceRecord instead.
+func (server *Server) submitClearanceRecord(ctx *gin.Context) {
+	id, err := getIDParam(ctx)
+	if err != nil {
+		return
+	}
+
+	var req SubmitClearanceRecordRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	err = server.store.DeleteClearanceRecord(ctx, id)
+	current, err := server.store.GetClearanceRecord(ctx, id)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorMessage("clearance record not found"))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	payload := authPayload(ctx)
+	if current.StudentID != payload.UserID {
+		forbidden(ctx)
+		return
+	}
+
+	if current.Status == db.RecordStatusRejected {
+		ctx.JSON(http.StatusConflict, errorCode("invalid_status_transition",
+			errors.New("rejected records must be resubmitted with a response note")))
+		return
+	}
+
+	note := req.Note
+	if note == "" {
+		note = current.Note
+	}
+
+	result, err := server.store.UpdateClearanceRecordStatusTx(ctx, db.UpdateClearanceRecordStatusTxParams{
+		RecordID:  id,
+		Status:    db.RecordStatusInReview,
+		Note:      note,
+		ActorRole: payload.Role,
+		ActorID:   payload.UserID,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrInvalidStatusTransition) {
+			ctx.JSON(http.StatusConflict, errorCode("invalid_status_transition", err))
+			return
+		}
+		if errors.Is(err, db.ErrAttachmentRequired) {
+			attachmentRequired(ctx, current, err)
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{"deleted": true})
+	item, err := server.store.GetRecordItem(ctx, id)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	server.sendNotification(ctx, approverToNotify(item.ApproverStaffID, item.AssignedStaffID), 0,
+		fmt.Sprintf("Clearance record %d for '%s' is ready for review.", id, item.Title))
+
+	ctx.JSON(http.StatusOK, result.Record)
+}
+
+// POST /clearance_records/:id/resubmit (multipart form: "note", optional "file")
+// The student answers a rejection with a response note and, for items that
+// require one, a new attachment.
+func (server *Server) resubmitClearanceRecord(ctx *gin.Context) {
+	id, err := getIDParam(ctx)
+	if err != nil {
+		return
+	}
+
+	current, err := server.store.GetClearanceRecord(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorMessage("clearance record not found"))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	payload := authPayload(ctx)
+	if current.StudentID != payload.UserID {
+		forbidden(ctx)
+		return
+	}
+
+	if current.Status != db.RecordStatusRejected {
+		ctx.JSON(http.StatusConflict, errorCode("invalid_status_transition",
+			fmt.Errorf("%w: only rejected records can be resubmitted", db.ErrInvalidStatusTransition)))
+		return
+	}
+
+	header, ok := server.formFile(ctx)
+	if !ok {
+		return
+	}
+
+	note := strings.TrimSpace(ctx.PostForm("note"))
+	if note == "" {
+		ctx.JSON(http.StatusBadRequest, validationError("note_required",
+			errors.New("a response note is required"), gin.H{"field": "note"}))
+		return
+	}
+
+	item, err := server.store.GetRecordItem(ctx, id)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// the file that was rejected does not count; a new one is needed
+	if item.RequiresAttachment && header == nil {
+		attachmentRequired(ctx, current, db.ErrAttachmentRequired)
+		return
+	}
+
+	arg := db.UpdateClearanceRecordStatusTxParams{
+		RecordID:         id,
+		Status:           db.RecordStatusResubmitted,
+		Note:             note,
+		ActorRole:        payload.Role,
+		ActorID:          payload.UserID,
+		MaxResubmissions: server.config.MaxResubmissions,
+	}
+
+	if header != nil {
+		attachment, ok := server.storeRecordFile(ctx, current, header)
+		if !ok {
+			return
+		}
+		arg.Attachment = &attachment
+	}
+
+	result, err := server.store.UpdateClearanceRecordStatusTx(ctx, arg)
+	if err != nil {
+		if arg.Attachment != nil {
+			// don't leave orphaned objects behind
+			_ = server.storage.Delete(ctx, arg.Attachment.StorageKey)
+		}
+		if errors.Is(err, db.ErrResubmissionLimitReached) {
+			ctx.JSON(http.StatusConflict, errorCode("resubmission_limit_reached", err))
+			return
+		}
+		if errors.Is(err, db.ErrInvalidStatusTransition) {
+			ctx.JSON(http.StatusConflict, errorCode("invalid_status_transition", err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.sendNotification(ctx, approverToNotify(item.ApproverStaffID, item.AssignedStaffID), 0,
+		fmt.Sprintf("Clearance record %d for '%s' was resubmitted. Response: %s", id, item.Title, note))
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"record":     result.Record,
+		"attachment": result.Attachment,
+	})
+}
+
+// GET /clearance_records/missing_attachments?session_id=
+// Staff see the records they approve; admins see every record.
+func (server *Server) listRecordsMissingAttachments(ctx *gin.Context) {
+	arg := db.ListRecordsMissingAttachmentsParams{}
+
+	if s := ctx.Query("session_id"); s != "" {
+		sessionID, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, errorMessage("invalid session_id"))
+			return
+		}
+		arg.SessionID = ToNullInt64(sessionID)
+	}
+
+	payload := authPayload(ctx)
+	if payload.Role != "admin" {
+		arg.ApproverStaffID = ToNullInt64(payload.UserID)
+	}
+
+	records, err := server.store.ListRecordsMissingAttachments(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, records)
 }
@@ -2,7 +2,9 @@ package api
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 
 	db "github.com/backendn/clearance_system/db/sqlc"
@@ -10,21 +12,37 @@ import (
 )
 
 type ClearanceRequestResponse struct {
-	ID        int64  `json:"id"`
-	StudentID int64  `json:"student_id"`
-	SessionID int64  `json:"session_id"`
-	Status    string `json:"status"`
-	CreatedAt string `json:"created_at"`
+	ID                int64  `json:"id"`
+	StudentID         int64  `json:"student_id"`
+	SessionID         int64  `json:"session_id"`
+	ClearanceTypeID   int64  `json:"clearance_type_id"`
+	ClearanceTypeCode string `json:"clearance_type_code"`
+	ClearanceTypeName string `json:"clearance_type_name"`
+	Status            string `json:"status"`
+	CreatedAt         string `json:"created_at"`
+	CompletedAt       string `json:"completed_at,omitempty"`
 }
 
-func convertClearanceRequest(r db.ClearanceRequest) ClearanceRequestResponse {
-	return ClearanceRequestResponse{
-		ID:        r.ID,
-		StudentID: r.StudentID,
-		SessionID: r.SessionID,
-		Status:    r.Status,
-		CreatedAt: r.CreatedAt.String(),
+type submitClearanceRequestRequest struct {
+	// Optional; the general clearance type is used when omitted.
+	ClearanceTypeID int64 `json:"clearance_type_id" binding:"omitempty,min=1"`
+}
+
+func convertClearanceRequest(r db.ClearanceRequest, t db.ClearanceType) ClearanceRequestResponse {
+	resp := ClearanceRequestResponse{
+		ID:                r.ID,
+		StudentID:         r.StudentID,
+		SessionID:         r.SessionID,
+		ClearanceTypeID:   r.ClearanceTypeID,
+		ClearanceTypeCode: t.Code,
+		ClearanceTypeName: t.Name,
+		Status:            r.Status,
+		CreatedAt:         r.CreatedAt.String(),
+	}
+	if r.CompletedAt.Valid {
+		resp.CompletedAt = r.CompletedAt.Time.String()
 	}
+	return resp
 }
 func (server *Server) SubmitClearanceRequest(ctx *gin.Context) {
 	studentID, err := getIDParam(ctx)
@@ -33,6 +51,13 @@ func (server *Server) SubmitClearanceRequest(ctx *gin.Context) {
 		return
 	}
 
+	// the body is optional, so an empty one is not an error
+	var req submitClearanceRequestRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
 	// 1. Validate student exists
 	student, err := server.store.GetStudent(ctx, studentID)
 	if err != nil {
@@ -55,77 +80,75 @@ func (server *Server) SubmitClearanceRequest(ctx *gin.Context) {
 		return
 	}
 
-	// 3. Ensure no duplicate request for this session
-	_, err = server.store.GetStudentRequestForSession(ctx,
-		db.GetStudentRequestForSessionParams{
-			StudentID: studentID,
-			SessionID: session.ID,
-		})
-	if err == nil {
-		ctx.JSON(http.StatusBadRequest, errorMessage("clearance request already submitted"))
+	// 3. Resolve the clearance type
+	var clearanceType db.ClearanceType
+	if req.ClearanceTypeID != 0 {
+		clearanceType, err = server.store.GetClearanceType(ctx, req.ClearanceTypeID)
+	} else {
+		clearanceType, err = server.store.GetClearanceTypeByCode(ctx, defaultClearanceTypeCode)
+	}
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusBadRequest, errorMessage("invalid clearance type ID"))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorMessage(err.Error()))
 		return
 	}
 
-	// 4. Create a new clearance request
-	req, err := server.store.CreateClearanceRequest(ctx, db.CreateClearanceRequestParams{
-		StudentID: studentID,
-		SessionID: session.ID,
+	// 4. Create the request and its records atomically. The unique
+	// constraint on (student_id, session_id, clearance_type_id) rejects
+	// duplicates.
+	result, err := server.store.SubmitClearanceRequestTx(ctx, db.SubmitClearanceRequestTxParams{
+		StudentID:       studentID,
+		SessionID:       session.ID,
+		ClearanceTypeID: clearanceType.ID,
 	})
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorMessage(err.Error()))
+		if errors.Is(err, db.ErrDuplicateClearanceRequest) {
+			ctx.JSON(http.StatusConflict, errorMessage(err.Error()))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorMessage("failed to create clearance workflow"))
 		return
 	}
 
+	// Notifications go out only once the transaction has committed.
+
 	// ---------------------------------------------
 	//  🔔 AUTO-NOTIFICATION #1 (to student)
 	// ---------------------------------------------
 	server.sendNotification(ctx, 0, studentID,
-		"Your clearance request has been submitted for session: "+session.Name)
-
-	// 5. Load all clearance items
-	items, err := server.store.ListClearanceItems(ctx)
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorMessage("failed to load clearance items"))
-		return
+		"Your "+clearanceType.Name+" request has been submitted for session: "+session.Name)
+
+	// -------------------------------------------------
+	//  🔔 AUTO-NOTIFICATION #2 (to department approver)
+	// -------------------------------------------------
+	// Only approvers of items that can be worked on now are notified; the
+	// rest hear about it once earlier items are approved.
+	fullName := student.FirstName + " " + student.LastName
+	assigned := make(map[int64]sql.NullInt64, len(result.Records))
+	for _, record := range result.Records {
+		assigned[record.ClearanceItemID] = record.AssignedStaffID
 	}
-
-	// 6. Create clearance_records for each clearance item
-	for _, item := range items {
-
-		record, err := server.store.CreateClearanceRecord(ctx, db.CreateClearanceRecordParams{
-			StudentID:       studentID,
-			ClearanceItemID: item.ID,
-			SessionID:       session.ID,
-		})
-		if err != nil {
-			ctx.JSON(http.StatusInternalServerError, errorMessage("failed to create clearance workflow"))
-			return
-		}
-
-		// -------------------------------------------------
-		//  🔔 AUTO-NOTIFICATION #2 (to department approver)
-		// -------------------------------------------------
-		fullName := student.FirstName + " " + student.LastName
-
+	for _, item := range result.Actionable {
 		server.sendNotification(ctx,
-			item.ApproverStaffID, // always valid
+			approverToNotify(item.ApproverStaffID, assigned[item.ID]),
 			0,
 			"New clearance request pending: "+fullName+
 				" - Item: "+item.Title)
-
-		_ = record
 	}
 
 	//  ----------------------------------------------
 	//  🔔 AUTO-NOTIFICATION #3 (confirmation to student)
 	//  ----------------------------------------------
 	server.sendNotification(ctx, 0, studentID,
-		"Your clearance workflow has been created with "+fmt.Sprint(len(items))+" items.")
+		"Your clearance workflow has been created with "+fmt.Sprint(len(result.Records))+" items.")
 
-	// 7. Respond
+	// 5. Respond
 	ctx.JSON(http.StatusCreated, gin.H{
 		"message": "clearance request submitted successfully",
-		"request": convertClearanceRequest(req),
+		"request": convertClearanceRequest(result.Request, clearanceType),
 	})
 }
 
@@ -143,10 +166,20 @@ func (server *Server) ListStudentRequests(ctx *gin.Context) {
 		return
 	}
 
+	types, err := server.store.ListClearanceTypes(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorMessage(err.Error()))
+		return
+	}
+	typesByID := make(map[int64]db.ClearanceType, len(types))
+	for _, t := range types {
+		typesByID[t.ID] = t
+	}
+
 	// convert
 	resp := make([]ClearanceRequestResponse, 0)
 	for _, r := range reqs {
-		resp = append(resp, convertClearanceRequest(r))
+		resp = append(resp, convertClearanceRequest(r, typesByID[r.ClearanceTypeID]))
 	}
 
 	ctx.JSON(http.StatusOK, gin.H{
@@ -170,5 +203,17 @@ func (server *Server) GetClearanceRequest(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, convertClearanceRequest(req))
+	payload := authPayload(ctx)
+	if payload.Role == "student" && req.StudentID != payload.UserID {
+		forbidden(ctx)
+		return
+	}
+
+	clearanceType, err := server.store.GetClearanceType(ctx, req.ClearanceTypeID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorMessage(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convertClearanceRequest(req, clearanceType))
 }
@@ -0,0 +1,246 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	db "github.com/backendn/clearance_system/db/sqlc"
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+)
+
+// sessionDateLayout is the format used for session start and end dates.
+const sessionDateLayout = "2006-01-02"
+
+type sessionRequest struct {
+	Name      string `json:"name" binding:"required"`
+	StartDate string `json:"start_date" binding:"required"`
+	EndDate   string `json:"end_date" binding:"required"`
+}
+
+// parseSessionDates parses the start and end dates of a session and makes
+// sure the range is not inverted.
+func parseSessionDates(req sessionRequest) (time.Time, time.Time, error) {
+	start, err := time.Parse(sessionDateLayout, req.StartDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.New("start_date must be in YYYY-MM-DD format")
+	}
+
+	end, err := time.Parse(sessionDateLayout, req.EndDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.New("end_date must be in YYYY-MM-DD format")
+	}
+
+	if end.Before(start) {
+		return time.Time{}, time.Time{}, errors.New("end_date must not be before start_date")
+	}
+
+	return start, end, nil
+}
+
+// POST /admins/sessions
+func (server *Server) createSession(ctx *gin.Context) {
+	var req sessionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	start, end, err := parseSessionDates(req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// New sessions are always created inactive; use the activate endpoint
+	// to open them so only one session is ever active.
+	session, err := server.store.CreateSession(ctx, db.CreateSessionParams{
+		Name:      req.Name,
+		StartDate: start,
+		EndDate:   end,
+		Active:    false,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, session)
+}
+
+// GET /admins/sessions
+func (server *Server) listSessions(ctx *gin.Context) {
+	sessions, err := server.store.ListSessions(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, sessions)
+}
+
+// GET /admins/sessions/:id
+func (server *Server) getSession(ctx *gin.Context) {
+	id, err := getIDParam(ctx)
+	if err != nil {
+		return
+	}
+
+	session, err := server.store.GetSession(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorMessage("clearance session not found"))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, session)
+}
+
+// PUT /admins/sessions/:id
+func (server *Server) updateSession(ctx *gin.Context) {
+	id, err := getIDParam(ctx)
+	if err != nil {
+		return
+	}
+
+	var req sessionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	start, end, err := parseSessionDates(req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	current, err := server.store.GetSession(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorMessage("clearance session not found"))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// The active flag is only changed through activate/deactivate.
+	session, err := server.store.UpdateSession(ctx, db.UpdateSessionParams{
+		Name:      req.Name,
+		StartDate: start,
+		EndDate:   end,
+		Active:    current.Active,
+		ID:        id,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, session)
+}
+
+// POST /admins/sessions/:id/activate
+func (server *Server) activateSession(ctx *gin.Context) {
+	id, err := getIDParam(ctx)
+	if err != nil {
+		return
+	}
+
+	var session db.ClearanceSession
+	err = server.store.ExecTx(ctx, func(q db.Querier) error {
+		if _, err := q.GetSession(ctx, id); err != nil {
+			return err
+		}
+
+		if err := q.DeactivateAllSessions(ctx); err != nil {
+			return err
+		}
+
+		if err := q.ActivateSession(ctx, id); err != nil {
+			return err
+		}
+
+		session, err = q.GetSession(ctx, id)
+		return err
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorMessage("clearance session not found"))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, session)
+}
+
+// POST /admins/sessions/:id/deactivate
+func (server *Server) deactivateSession(ctx *gin.Context) {
+	id, err := getIDParam(ctx)
+	if err != nil {
+		return
+	}
+
+	current, err := server.store.GetSession(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorMessage("clearance session not found"))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	session, err := server.store.UpdateSession(ctx, db.UpdateSessionParams{
+		Name:      current.Name,
+		StartDate: current.StartDate,
+		EndDate:   current.EndDate,
+		Active:    false,
+		ID:        id,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, session)
+}
+
+// DELETE /admins/sessions/:id
+func (server *Server) deleteSession(ctx *gin.Context) {
+	id, err := getIDParam(ctx)
+	if err != nil {
+		return
+	}
+
+	if _, err := server.store.GetSession(ctx, id); err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorMessage("clearance session not found"))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// the foreign keys of requests and records keep sessions in use; a
+	// separate count would race with submissions
+	if err := server.store.DeleteSession(ctx, id); err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23503" {
+			ctx.JSON(http.StatusConflict, errorMessage("cannot delete a session that has clearance requests or records"))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "deleted"})
+}
@@ -0,0 +1,137 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+
+	db "github.com/backendn/clearance_system/db/sqlc"
+	"github.com/gin-gonic/gin"
+)
+
+// defaultClearanceTypeCode is used when a request is submitted without a type.
+const defaultClearanceTypeCode = "general"
+
+type clearanceTypeRequest struct {
+	Code        string `json:"code" binding:"required"`
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+}
+
+// POST /admins/clearance_types
+func (server *Server) createClearanceType(ctx *gin.Context) {
+	var req clearanceTypeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if _, err := server.store.GetClearanceTypeByCode(ctx, req.Code); err == nil {
+		ctx.JSON(http.StatusBadRequest, errorMessage("clearance type code already exists"))
+		return
+	}
+
+	clearanceType, err := server.store.CreateClearanceType(ctx, db.CreateClearanceTypeParams{
+		Code:        req.Code,
+		Name:        req.Name,
+		Description: req.Description,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, clearanceType)
+}
+
+// GET /clearance_types
+func (server *Server) listClearanceTypes(ctx *gin.Context) {
+	types, err := server.store.ListClearanceTypes(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, types)
+}
+
+// GET /clearance_types/:id
+func (server *Server) getClearanceType(ctx *gin.Context) {
+	id, err := getIDParam(ctx)
+	if err != nil {
+		return
+	}
+
+	clearanceType, err := server.store.GetClearanceType(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorMessage("clearance type not found"))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, clearanceType)
+}
+
+// PUT /admins/clearance_types/:id
+func (server *Server) updateClearanceType(ctx *gin.Context) {
+	id, err := getIDParam(ctx)
+	if err != nil {
+		return
+	}
+
+	var req clearanceTypeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	existing, err := server.store.GetClearanceTypeByCode(ctx, req.Code)
+	if err == nil && existing.ID != id {
+		ctx.JSON(http.StatusBadRequest, errorMessage("clearance type code already exists"))
+		return
+	}
+
+	clearanceType, err := server.store.UpdateClearanceType(ctx, db.UpdateClearanceTypeParams{
+		Code:        req.Code,
+		Name:        req.Name,
+		Description: req.Description,
+		ID:          id,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorMessage("clearance type not found"))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, clearanceType)
+}
+
+// DELETE /admins/clearance_types/:id
+func (server *Server) deleteClearanceType(ctx *gin.Context) {
+	id, err := getIDParam(ctx)
+	if err != nil {
+		return
+	}
+
+	count, err := server.store.CountRequestsByType(ctx, id)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if count > 0 {
+		ctx.JSON(http.StatusConflict, errorMessage("clearance type is still used by clearance requests"))
+		return
+	}
+
+	if err := server.store.DeleteClearanceType(ctx, id); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "deleted"})
+}
@@ -0,0 +1,158 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	db "github.com/backendn/clearance_system/db/sqlc"
+	"github.com/gin-gonic/gin"
+)
+
+type createDelegationRequest struct {
+	SubstituteStaffID int64  `json:"substitute_staff_id" binding:"required,min=1"`
+	StartsOn          string `json:"starts_on" binding:"required"`
+	EndsOn            string `json:"ends_on" binding:"required"`
+	Reason            string `json:"reason"`
+}
+
+type adminCreateDelegationRequest struct {
+	DelegatorStaffID int64 `json:"delegator_staff_id" binding:"required,min=1"`
+	createDelegationRequest
+}
+
+// POST /me/delegations
+// Hands the caller's approvals to a substitute for an inclusive date range.
+func (server *Server) createDelegation(ctx *gin.Context) {
+	var req createDelegationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	server.saveDelegation(ctx, authPayload(ctx).UserID, req)
+}
+
+// POST /admins/delegations
+// Lets an admin set up a delegation for an approver who can't do it
+// themselves.
+func (server *Server) adminCreateDelegation(ctx *gin.Context) {
+	var req adminCreateDelegationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if _, err := server.store.GetStaffUser(ctx, req.DelegatorStaffID); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorMessage("invalid delegator staff ID"))
+		return
+	}
+
+	server.saveDelegation(ctx, req.DelegatorStaffID, req.createDelegationRequest)
+}
+
+// saveDelegation validates and stores a delegation from delegatorID and
+// tells the substitute about it.
+func (server *Server) saveDelegation(ctx *gin.Context, delegatorID int64, req createDelegationRequest) {
+	start, end, err := parseSessionDates(sessionRequest{StartDate: req.StartsOn, EndDate: req.EndsOn})
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorMessage(
+			"starts_on and ends_on must be YYYY-MM-DD dates and ends_on must not be before starts_on"))
+		return
+	}
+
+	today, _ := time.Parse(sessionDateLayout, time.Now().Format(sessionDateLayout))
+	if end.Before(today) {
+		ctx.JSON(http.StatusBadRequest, errorMessage("ends_on must not be in the past"))
+		return
+	}
+
+	if req.SubstituteStaffID == delegatorID {
+		ctx.JSON(http.StatusBadRequest, errorMessage("you cannot delegate to yourself"))
+		return
+	}
+	if _, err := server.store.GetStaffUser(ctx, req.SubstituteStaffID); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorMessage("invalid substitute staff ID"))
+		return
+	}
+
+	// one substitute at a time keeps "on behalf of" unambiguous
+	overlapping, err := server.store.CountOverlappingDelegations(ctx, db.CountOverlappingDelegationsParams{
+		DelegatorStaffID: delegatorID,
+		StartsOn:         start,
+		EndsOn:           end,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if overlapping > 0 {
+		ctx.JSON(http.StatusConflict, errorCode("delegation_overlap",
+			errors.New("a delegation already covers part of this date range")))
+		return
+	}
+
+	delegation, err := server.store.CreateApproverDelegation(ctx, db.CreateApproverDelegationParams{
+		DelegatorStaffID:  delegatorID,
+		SubstituteStaffID: req.SubstituteStaffID,
+		StartsOn:          start,
+		EndsOn:            end,
+		Reason:            req.Reason,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.sendNotification(ctx, delegation.SubstituteStaffID, 0,
+		fmt.Sprintf("You are substituting for approver %d from %s to %s.", delegation.DelegatorStaffID,
+			delegation.StartsOn.Format(sessionDateLayout), delegation.EndsOn.Format(sessionDateLayout)))
+
+	ctx.JSON(http.StatusCreated, delegation)
+}
+
+// GET /me/delegations
+// Lists the delegations the caller gave and received.
+func (server *Server) listMyDelegations(ctx *gin.Context) {
+	delegations, err := server.store.ListApproverDelegationsForStaff(ctx, authPayload(ctx).UserID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, delegations)
+}
+
+// DELETE /me/delegations/:id and DELETE /admins/delegations/:id
+// Decisions already made keep their "on behalf of" entry in the history.
+func (server *Server) deleteDelegation(ctx *gin.Context) {
+	id, err := getIDParam(ctx)
+	if err != nil {
+		return
+	}
+
+	delegation, err := server.store.GetApproverDelegation(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorMessage("delegation not found"))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	payload := authPayload(ctx)
+	if payload.Role != "admin" && delegation.DelegatorStaffID != payload.UserID {
+		forbidden(ctx)
+		return
+	}
+
+	if err := server.store.DeleteApproverDelegation(ctx, id); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "deleted"})
+}
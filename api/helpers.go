@@ -29,6 +29,16 @@ func errorMessage(msg string) gin.H {
 func errorResponse(err error) gin.H {
 	return gin.H{"error": err.Error()}
 }
+
+// errorCode wraps an error object into JSON together with a machine readable code
+func errorCode(code string, err error) gin.H {
+	return gin.H{"error": err.Error(), "code": code}
+}
+
+// validationError is errorCode plus details about what failed validation
+func validationError(code string, err error, details gin.H) gin.H {
+	return gin.H{"error": err.Error(), "code": code, "details": details}
+}
 func getPagination(ctx *gin.Context) (limit, offset int) {
 	limitQuery := ctx.DefaultQuery("limit", "10")
 	offsetQuery := ctx.DefaultQuery("offset", "0")
@@ -0,0 +1,141 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	db "github.com/backendn/clearance_system/db/sqlc"
+	"github.com/gin-gonic/gin"
+)
+
+// isItemGraphError reports whether err means the saved prerequisite links
+// would make the item graph invalid.
+func isItemGraphError(err error) bool {
+	return errors.Is(err, db.ErrPrerequisiteCycle) || errors.Is(err, db.ErrPrerequisiteTypeMismatch)
+}
+
+// validPrerequisites checks that every prerequisite ID names another
+// existing clearance item. It writes the 400 itself.
+func (s *Server) validPrerequisites(ctx *gin.Context, itemID int64, prerequisiteIDs []int64) bool {
+	for _, id := range prerequisiteIDs {
+		if id == itemID {
+			ctx.JSON(http.StatusBadRequest, errorMessage("a clearance item cannot be its own prerequisite"))
+			return false
+		}
+		if _, err := s.store.GetClearanceItem(ctx, id); err != nil {
+			ctx.JSON(http.StatusBadRequest, errorMessage("invalid prerequisite item ID"))
+			return false
+		}
+	}
+	return true
+}
+
+// GET /clearance_items/:id/prerequisites
+func (s *Server) listItemPrerequisites(ctx *gin.Context) {
+	id, err := getIDParam(ctx)
+	if err != nil {
+		return
+	}
+
+	if _, err := s.store.GetClearanceItem(ctx, id); err != nil {
+		ctx.JSON(http.StatusNotFound, errorMessage("clearance item not found"))
+		return
+	}
+
+	prerequisites, err := s.store.ListItemPrerequisites(ctx, id)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, prerequisites)
+}
+
+type requestGraphNode struct {
+	RecordID        int64         `json:"record_id"`
+	ItemID          int64         `json:"item_id"`
+	Title           string        `json:"title"`
+	Status          string        `json:"status"`
+	Sequence        int32         `json:"sequence"`
+	EnforceSequence bool          `json:"enforce_sequence"`
+	ApproverStaffID int64         `json:"approver_staff_id"`
+	AssignedStaffID sql.NullInt64 `json:"assigned_staff_id"`
+	Actionable      bool          `json:"actionable"`
+}
+
+// requestGraphEdge points from a prerequisite record to the record that
+// waits for it.
+type requestGraphEdge struct {
+	FromRecordID int64 `json:"from_record_id"`
+	ToRecordID   int64 `json:"to_record_id"`
+}
+
+// GET /clearance_requests/:id/graph
+// Returns the records of a request and the prerequisite links between them
+// so the dependency graph can be drawn. Links to items the student has no
+// record for are left out, just as they are when deciding what is
+// actionable.
+func (s *Server) getClearanceRequestGraph(ctx *gin.Context) {
+	reqID, err := getIDParam(ctx)
+	if err != nil {
+		return
+	}
+
+	req, err := s.store.GetClearanceRequest(ctx, reqID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorMessage("clearance request not found"))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	payload := authPayload(ctx)
+	if payload.Role == "student" && req.StudentID != payload.UserID {
+		forbidden(ctx)
+		return
+	}
+
+	rows, err := s.store.ListRequestRecordItems(ctx, sql.NullInt64{Int64: req.ID, Valid: true})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	actionable := db.ActionableRecords(rows)
+	recordOfItem := make(map[int64]int64, len(rows))
+	for _, row := range rows {
+		recordOfItem[row.ItemID] = row.RecordID
+	}
+
+	nodes := make([]requestGraphNode, 0, len(rows))
+	edges := make([]requestGraphEdge, 0)
+	for _, row := range rows {
+		nodes = append(nodes, requestGraphNode{
+			RecordID:        row.RecordID,
+			ItemID:          row.ItemID,
+			Title:           row.Title,
+			Status:          row.Status,
+			Sequence:        row.Sequence,
+			EnforceSequence: row.EnforceSequence,
+			ApproverStaffID: row.ApproverStaffID,
+			AssignedStaffID: row.AssignedStaffID,
+			Actionable:      actionable[row.RecordID],
+		})
+
+		for _, prerequisite := range row.PrerequisiteItemIds {
+			if from, ok := recordOfItem[prerequisite]; ok {
+				edges = append(edges, requestGraphEdge{FromRecordID: from, ToRecordID: row.RecordID})
+			}
+		}
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"request_id": req.ID,
+		"status":     req.Status,
+		"nodes":      nodes,
+		"edges":      edges,
+	})
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"net/http"
 	"time"
 
 	db "github.com/backendn/clearance_system/db/sqlc"
@@ -70,3 +71,58 @@ func (server *Server) Login(ctx *gin.Context) {
 
 	ctx.JSON(200, resp)
 }
+
+type studentLoginRequest struct {
+	StudentNumber string `json:"student_number" binding:"required"`
+	Password      string `json:"password" binding:"required"`
+}
+
+type studentLoginResponse struct {
+	AccessToken string         `json:"access_token"`
+	Payload     *token.Payload `json:"payload"`
+	Student     db.Student     `json:"student"`
+}
+
+// StudentLogin authenticates a student by student number and password and
+// issues a token with the "student" role.
+func (server *Server) StudentLogin(ctx *gin.Context) {
+	var req studentLoginRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	student, err := server.store.GetStudentByStudentNumber(ctx, req.StudentNumber)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorMessage("invalid credentials"))
+		return
+	}
+
+	// Students without a credential row have not been given a password yet.
+	credential, err := server.store.GetStudentCredential(ctx, student.ID)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorMessage("invalid credentials"))
+		return
+	}
+
+	if err := util.CheckPassword(req.Password, credential.HashedPassword); err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorMessage("invalid credentials"))
+		return
+	}
+
+	tokenString, payload, err := server.tokenMaker.CreateToken(
+		student.ID,
+		"student",
+		time.Hour,
+	)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorMessage("cannot create token"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, studentLoginResponse{
+		AccessToken: tokenString,
+		Payload:     payload,
+		Student:     student,
+	})
+}
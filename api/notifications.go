@@ -69,23 +69,49 @@ func (server *Server) CreateNotification(ctx *gin.Context) {
 // Get Single Notification
 // ================================
 func (server *Server) GetNotification(ctx *gin.Context) {
+	n, ok := server.loadOwnNotification(ctx)
+	if !ok {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convertNotification(n))
+}
+
+// loadOwnNotification loads the notification in the :id parameter and
+// checks that the caller received it; admins may reach any notification.
+// It writes the error response itself and reports whether to go on.
+func (server *Server) loadOwnNotification(ctx *gin.Context) (db.Notification, bool) {
 	id, err := getIDParam(ctx)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorMessage("invalid notification ID"))
-		return
+		return db.Notification{}, false
 	}
 
 	n, err := server.store.GetNotification(ctx, id)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			ctx.JSON(http.StatusNotFound, errorMessage("notification not found"))
-			return
+			return db.Notification{}, false
 		}
 		ctx.JSON(http.StatusInternalServerError, errorMessage(err.Error()))
-		return
+		return db.Notification{}, false
 	}
 
-	ctx.JSON(http.StatusOK, convertNotification(n))
+	payload := authPayload(ctx)
+	var recipient sql.NullInt64
+	switch payload.Role {
+	case "admin":
+		return n, true
+	case "student":
+		recipient = n.RecipientStudentID
+	default:
+		recipient = n.RecipientUserID
+	}
+	if !recipient.Valid || recipient.Int64 != payload.UserID {
+		forbidden(ctx)
+		return db.Notification{}, false
+	}
+
+	return n, true
 }
 
 // ================================
@@ -140,13 +166,12 @@ func (server *Server) ListNotificationsForStudent(ctx *gin.Context) {
 // Mark Notification as Read
 // ================================
 func (server *Server) MarkNotificationRead(ctx *gin.Context) {
-	id, err := getIDParam(ctx)
-	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorMessage("invalid notification ID"))
+	n, ok := server.loadOwnNotification(ctx)
+	if !ok {
 		return
 	}
 
-	n, err := server.store.MarkNotificationRead(ctx, id)
+	n, err := server.store.MarkNotificationRead(ctx, n.ID)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			ctx.JSON(http.StatusNotFound, errorMessage("notification not found"))
@@ -163,13 +188,12 @@ func (server *Server) MarkNotificationRead(ctx *gin.Context) {
 // Delete Notification
 // ================================
 func (server *Server) DeleteNotification(ctx *gin.Context) {
-	id, err := getIDParam(ctx)
-	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorMessage("invalid notification ID"))
+	n, ok := server.loadOwnNotification(ctx)
+	if !ok {
 		return
 	}
 
-	err = server.store.DeleteNotification(ctx, id)
+	err := server.store.DeleteNotification(ctx, n.ID)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorMessage(err.Error()))
 		return
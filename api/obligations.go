@@ -0,0 +1,261 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	db "github.com/backendn/clearance_system/db/sqlc"
+	"github.com/gin-gonic/gin"
+)
+
+// defaultObligationCurrency is used for obligations raised without one.
+const defaultObligationCurrency = "USD"
+
+type raiseObligationRequest struct {
+	StudentID int64 `json:"student_id" binding:"required,min=1"`
+	// Required for admins; staff always raise obligations for their own
+	// department.
+	DepartmentID   int64  `json:"department_id" binding:"omitempty,min=1"`
+	ObligationType string `json:"obligation_type" binding:"required,oneof=book equipment key fee other"`
+	Description    string `json:"description"`
+	// Amount is in the currency's minor unit, e.g. cents.
+	Amount   int64  `json:"amount" binding:"min=0"`
+	Currency string `json:"currency" binding:"omitempty,len=3,uppercase"`
+}
+
+type resolveObligationRequest struct {
+	Status string `json:"status" binding:"required,oneof=resolved waived"`
+	Note   string `json:"note"`
+}
+
+// staffDepartment returns the department of a staff caller. Admins are not
+// tied to a department and get false.
+func (server *Server) staffDepartment(ctx *gin.Context) (int64, bool, error) {
+	payload := authPayload(ctx)
+	if payload.Role != "staff" {
+		return 0, false, nil
+	}
+
+	staff, err := server.store.GetStaffUser(ctx, payload.UserID)
+	if err != nil {
+		return 0, false, err
+	}
+	return staff.DepartmentID, true, nil
+}
+
+// POST /obligations
+// Records something a student owes the caller's department. Approval of
+// the department's clearance items is blocked until it is resolved.
+func (server *Server) raiseObligation(ctx *gin.Context) {
+	var req raiseObligationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if req.Currency == "" {
+		req.Currency = defaultObligationCurrency
+	}
+
+	departmentID, isStaff, err := server.staffDepartment(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if isStaff {
+		if req.DepartmentID != 0 && req.DepartmentID != departmentID {
+			forbidden(ctx)
+			return
+		}
+		req.DepartmentID = departmentID
+	}
+	if req.DepartmentID == 0 {
+		ctx.JSON(http.StatusBadRequest, errorMessage("department_id is required"))
+		return
+	}
+
+	if _, err := server.store.GetDepartment(ctx, req.DepartmentID); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorMessage("invalid department ID"))
+		return
+	}
+	if _, err := server.store.GetStudent(ctx, req.StudentID); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorMessage("invalid student ID"))
+		return
+	}
+
+	payload := authPayload(ctx)
+	obligation, err := server.store.CreateStudentObligation(ctx, db.CreateStudentObligationParams{
+		StudentID:      req.StudentID,
+		DepartmentID:   req.DepartmentID,
+		ObligationType: req.ObligationType,
+		Description:    req.Description,
+		Amount:         req.Amount,
+		Currency:       req.Currency,
+		RaisedByRole:   payload.Role,
+		RaisedByID:     payload.UserID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.sendNotification(ctx, 0, obligation.StudentID,
+		fmt.Sprintf("A new obligation was recorded against you: %s.", describeObligation(obligation)))
+
+	ctx.JSON(http.StatusCreated, obligation)
+}
+
+// GET /obligations?student_id=&department_id=&status=
+// Staff only see their own department's obligations.
+func (server *Server) listObligations(ctx *gin.Context) {
+	var filter struct {
+		StudentID    int64  `form:"student_id" binding:"omitempty,min=1"`
+		DepartmentID int64  `form:"department_id" binding:"omitempty,min=1"`
+		Status       string `form:"status" binding:"omitempty,oneof=open resolved waived"`
+	}
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	departmentID, isStaff, err := server.staffDepartment(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if isStaff {
+		if filter.DepartmentID != 0 && filter.DepartmentID != departmentID {
+			forbidden(ctx)
+			return
+		}
+		filter.DepartmentID = departmentID
+	}
+
+	limit, offset := getPagination(ctx)
+	obligations, err := server.store.ListObligations(ctx, db.ListObligationsParams{
+		DepartmentID: ToNullInt64(filter.DepartmentID),
+		StudentID:    ToNullInt64(filter.StudentID),
+		Status:       NullableString(filter.Status),
+		PageLimit:    int32(limit),
+		PageOffset:   int32(offset),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, obligations)
+}
+
+// POST /obligations/:id/resolve
+// Marks an open obligation as resolved or waived. Only staff of the
+// department that raised it, and admins, may do so.
+func (server *Server) resolveObligation(ctx *gin.Context) {
+	id, err := getIDParam(ctx)
+	if err != nil {
+		return
+	}
+
+	var req resolveObligationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	obligation, err := server.store.GetStudentObligation(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorMessage("obligation not found"))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	departmentID, isStaff, err := server.staffDepartment(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if isStaff && obligation.DepartmentID != departmentID {
+		forbidden(ctx)
+		return
+	}
+
+	payload := authPayload(ctx)
+	obligation, err = server.store.ResolveStudentObligation(ctx, db.ResolveStudentObligationParams{
+		ID:             id,
+		Status:         req.Status,
+		ResolvedByRole: NullableString(payload.Role),
+		ResolvedByID:   ToNullInt64(payload.UserID),
+		ResolutionNote: req.Note,
+	})
+	if err != nil {
+		// the update only matches open obligations
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusConflict, errorCode("obligation_closed",
+				errors.New("the obligation is already resolved or waived")))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.sendNotification(ctx, 0, obligation.StudentID,
+		fmt.Sprintf("Your obligation %s was marked %s.", describeObligation(obligation), obligation.Status))
+
+	ctx.JSON(http.StatusOK, obligation)
+}
+
+// GET /students/:id/obligations
+// Lists everything the student owes or owed, open obligations first, with
+// the open amounts totalled per currency.
+func (server *Server) listStudentObligations(ctx *gin.Context) {
+	studentID, err := getIDParam(ctx)
+	if err != nil {
+		return
+	}
+
+	obligations, err := server.store.ListStudentObligations(ctx, studentID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	openTotals := map[string]int64{}
+	open := 0
+	for _, o := range obligations {
+		if o.Status != db.ObligationStatusOpen {
+			continue
+		}
+		open++
+		if o.Amount > 0 {
+			openTotals[o.Currency] += o.Amount
+		}
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"obligations": obligations,
+		"open":        open,
+		"open_totals": openTotals,
+	})
+}
+
+// describeObligation renders an obligation for notifications, e.g.
+// "fee of 25.00 USD (lab coat)".
+func describeObligation(o db.StudentObligation) string {
+	text := o.ObligationType
+	if o.Amount > 0 {
+		text += " of " + formatAmount(o.Amount, o.Currency)
+	}
+	if o.Description != "" {
+		text += " (" + o.Description + ")"
+	}
+	return text
+}
+
+// formatAmount renders an amount in minor units, e.g. "25.00 USD".
+func formatAmount(amount int64, currency string) string {
+	return fmt.Sprintf("%d.%02d %s", amount/100, amount%100, currency)
+}
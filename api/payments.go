@@ -0,0 +1,268 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	db "github.com/backendn/clearance_system/db/sqlc"
+	"github.com/backendn/clearance_system/payment"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// idempotencyKeyHeader lets clients retry a payment without paying twice;
+// its value becomes the payment's reference.
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// maxWebhookSize bounds the callback bodies read from providers.
+const maxWebhookSize = 64 << 10
+
+// POST /obligations/:id/payments
+// Starts an online payment of an obligation's fee and returns the checkout
+// the student completes at the provider. Sending the same Idempotency-Key
+// again returns the payment created the first time, and while a payment
+// of the obligation is open it is returned instead of starting another.
+func (server *Server) createObligationPayment(ctx *gin.Context) {
+	id, err := getIDParam(ctx)
+	if err != nil {
+		return
+	}
+
+	obligation, err := server.store.GetStudentObligation(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorMessage("obligation not found"))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	payload := authPayload(ctx)
+	if obligation.StudentID != payload.UserID {
+		forbidden(ctx)
+		return
+	}
+
+	reference := ctx.GetHeader(idempotencyKeyHeader)
+	if len(reference) > 100 {
+		ctx.JSON(http.StatusBadRequest, errorMessage(idempotencyKeyHeader+" must be at most 100 characters"))
+		return
+	}
+	if reference == "" {
+		reference = uuid.NewString()
+	}
+
+	existing, err := server.store.GetPaymentByReference(ctx, reference)
+	if err == nil {
+		replyExistingPayment(ctx, existing, obligation)
+		return
+	}
+	if err != sql.ErrNoRows {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if obligation.Status != db.ObligationStatusOpen {
+		ctx.JSON(http.StatusConflict, errorCode("obligation_closed",
+			errors.New("the obligation is already resolved or waived")))
+		return
+	}
+	if obligation.Amount == 0 {
+		ctx.JSON(http.StatusBadRequest, errorMessage("the obligation has no fee to pay"))
+		return
+	}
+
+	// a payment already in flight is resumed rather than charged again
+	active, err := server.store.GetActiveObligationPayment(ctx, obligation.ID)
+	if err == nil {
+		replyActivePayment(ctx, active)
+		return
+	}
+	if err != sql.ErrNoRows {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	created, err := server.store.CreatePayment(ctx, db.CreatePaymentParams{
+		ObligationID: obligation.ID,
+		StudentID:    obligation.StudentID,
+		Reference:    reference,
+		Provider:     server.payments.Name(),
+		Amount:       obligation.Amount,
+		Currency:     obligation.Currency,
+	})
+	if err != nil {
+		// a concurrent request got there first, either with the same key or
+		// with another payment of the obligation
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			if existing, err := server.store.GetPaymentByReference(ctx, reference); err == nil {
+				replyExistingPayment(ctx, existing, obligation)
+				return
+			}
+			active, err := server.store.GetActiveObligationPayment(ctx, obligation.ID)
+			if err != nil {
+				ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+				return
+			}
+			replyActivePayment(ctx, active)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	checkout, err := server.payments.CreateCheckout(ctx, payment.CheckoutRequest{
+		Reference:   created.Reference,
+		Amount:      created.Amount,
+		Currency:    created.Currency,
+		Description: describeObligation(obligation),
+	})
+	if err != nil {
+		if _, failErr := server.store.CompletePayment(ctx, db.CompletePaymentParams{
+			ID:            created.ID,
+			Status:        db.PaymentStatusFailed,
+			FailureReason: err.Error(),
+		}); failErr != nil {
+			ctx.Error(failErr)
+		}
+		ctx.JSON(http.StatusBadGateway, errorMessage("the payment provider is unavailable, please try again"))
+		return
+	}
+
+	created, err = server.store.SetPaymentCheckout(ctx, db.SetPaymentCheckoutParams{
+		ID:                created.ID,
+		ProviderPaymentID: checkout.ProviderPaymentID,
+		CheckoutUrl:       checkout.URL,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, created)
+}
+
+// replyExistingPayment answers a retried payment. The payment is returned
+// as is when the reference was used for the same obligation; a reference
+// used for anything else is a conflict.
+func replyExistingPayment(ctx *gin.Context, existing db.Payment, obligation db.StudentObligation) {
+	if existing.ObligationID != obligation.ID || existing.StudentID != obligation.StudentID {
+		ctx.JSON(http.StatusConflict, errorCode("reference_in_use",
+			errors.New("the idempotency key was already used for another payment")))
+		return
+	}
+	ctx.JSON(http.StatusOK, existing)
+}
+
+// replyActivePayment answers a payment request for an obligation that
+// already has a payment open or paid. An open payment is returned so the
+// student can finish its checkout.
+func replyActivePayment(ctx *gin.Context, active db.Payment) {
+	if active.Status == db.PaymentStatusSucceeded {
+		ctx.JSON(http.StatusConflict, errorCode("obligation_paid",
+			errors.New("the obligation has already been paid")))
+		return
+	}
+	ctx.JSON(http.StatusOK, active)
+}
+
+// GET /students/:id/payments
+func (server *Server) listStudentPayments(ctx *gin.Context) {
+	studentID, err := getIDParam(ctx)
+	if err != nil {
+		return
+	}
+
+	payments, err := server.store.ListStudentPayments(ctx, studentID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, payments)
+}
+
+// POST /payments/webhook
+// Receives payment callbacks from the provider. It is not authenticated
+// with a token; the provider's signature is verified instead. Repeated
+// callbacks for the same payment are acknowledged without side effects.
+func (server *Server) paymentWebhook(ctx *gin.Context) {
+	body, err := io.ReadAll(io.LimitReader(ctx.Request.Body, maxWebhookSize))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	event, err := server.payments.ParseWebhook(ctx.Request.Header, body)
+	if err != nil {
+		if errors.Is(err, payment.ErrInvalidSignature) {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	result, err := server.store.ConfirmPaymentTx(ctx, db.ConfirmPaymentTxParams{
+		Reference:         event.Reference,
+		ProviderPaymentID: event.ProviderPaymentID,
+		Succeeded:         event.Status == payment.StatusSucceeded,
+		Amount:            event.Amount,
+		Currency:          event.Currency,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorMessage("unknown payment reference"))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if !result.Duplicate {
+		server.notifyPaymentResult(ctx, result)
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"received": true, "duplicate": result.Duplicate})
+}
+
+// notifyPaymentResult tells the student how their payment went and the
+// approvers of records that moved forward that they can continue.
+func (server *Server) notifyPaymentResult(ctx *gin.Context, result db.ConfirmPaymentTxResult) {
+	paid := result.Payment
+	amount := formatAmount(paid.Amount, paid.Currency)
+
+	if paid.Status != db.PaymentStatusSucceeded {
+		server.sendNotification(ctx, 0, paid.StudentID,
+			fmt.Sprintf("Your payment of %s failed: %s.", amount, paid.FailureReason))
+		return
+	}
+
+	if result.Unapplied {
+		ctx.Error(fmt.Errorf("payment %s succeeded for a closed obligation and needs a refund", paid.Reference))
+		server.sendNotification(ctx, 0, paid.StudentID,
+			fmt.Sprintf("Your payment of %s (reference %s) was received, but the obligation was already closed. It will be refunded.",
+				amount, paid.Reference))
+		return
+	}
+
+	server.sendNotification(ctx, 0, paid.StudentID,
+		fmt.Sprintf("Your payment of %s was received (reference %s).", amount, paid.Reference))
+
+	for _, advanced := range result.Advanced {
+		record := advanced.Record
+		item, err := server.store.GetClearanceItem(ctx, record.ClearanceItemID)
+		if err != nil {
+			ctx.Error(err)
+			continue
+		}
+		server.sendNotification(ctx, approverToNotify(item.ApproverStaffID, record.AssignedStaffID), 0,
+			fmt.Sprintf("Clearance item '%s' is ready for review: the student paid what they owed.", item.Title))
+	}
+}
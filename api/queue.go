@@ -0,0 +1,224 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	db "github.com/backendn/clearance_system/db/sqlc"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultQueueLimit = 20
+	maxQueueLimit     = 100
+)
+
+// queueStatuses are the statuses that wait on the approver. in_review is
+// included because it is how a student marks a record ready for review.
+var queueStatuses = []string{
+	db.RecordStatusPending,
+	db.RecordStatusInReview,
+	db.RecordStatusResubmitted,
+}
+
+type queueItem struct {
+	RecordID       int64  `json:"record_id"`
+	RequestID      int64  `json:"request_id,omitempty"`
+	SessionID      int64  `json:"session_id"`
+	SessionName    string `json:"session_name"`
+	Status         string `json:"status"`
+	StudentID      int64  `json:"student_id"`
+	StudentNumber  string `json:"student_number"`
+	StudentName    string `json:"student_name"`
+	DepartmentID   int64  `json:"department_id"`
+	ItemID         int64  `json:"item_id"`
+	ItemTitle      string `json:"item_title"`
+	WaitingSince   string `json:"waiting_since"`
+	WaitingSeconds int64  `json:"waiting_seconds"`
+	// OnBehalfOf is the absent approver for records delegated to the caller.
+	OnBehalfOf int64 `json:"on_behalf_of,omitempty"`
+	// Claimable marks pooled records nobody has claimed yet.
+	Claimable bool `json:"claimable"`
+}
+
+// encodeQueueCursor turns the position of the last row of a page into an
+// opaque cursor.
+func encodeQueueCursor(updatedAt time.Time, id int64) string {
+	raw := fmt.Sprintf("%d:%d", updatedAt.UnixNano(), id)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeQueueCursor(cursor string) (time.Time, int64, error) {
+	errInvalid := errors.New("invalid cursor")
+
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, 0, errInvalid
+	}
+
+	nanos, id, found := strings.Cut(string(raw), ":")
+	if !found {
+		return time.Time{}, 0, errInvalid
+	}
+
+	n, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return time.Time{}, 0, errInvalid
+	}
+	recordID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return time.Time{}, 0, errInvalid
+	}
+
+	return time.Unix(0, n), recordID, nil
+}
+
+// optionalID parses an optional positive ID query parameter.
+func optionalID(ctx *gin.Context, name string) (sql.NullInt64, error) {
+	s := ctx.Query(name)
+	if s == "" {
+		return sql.NullInt64{}, nil
+	}
+	id, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || id < 1 {
+		return sql.NullInt64{}, fmt.Errorf("invalid %s", name)
+	}
+	return ToNullInt64(id), nil
+}
+
+// GET /me/queue
+// Lists the records waiting on the caller: records they approve or were
+// assigned, records of approvers who delegated to them for today, and
+// unclaimed records of the approver pools they belong to.
+//
+// Query parameters:
+//
+//	session_id, department_id  filter by session or by the student's department
+//	status                     pending, in_review or resubmitted (repeatable)
+//	min_age_hours              only records waiting at least this long
+//	sort                       oldest (default) or newest
+//	limit, cursor              page size and the next_cursor of the previous page
+func (server *Server) getApproverQueue(ctx *gin.Context) {
+	payload := authPayload(ctx)
+
+	arg := db.ListApproverQueueParams{
+		ApproverStaffID: payload.UserID,
+		Statuses:        queueStatuses,
+	}
+
+	var err error
+	if arg.SessionID, err = optionalID(ctx, "session_id"); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if arg.DepartmentID, err = optionalID(ctx, "department_id"); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if statuses := ctx.QueryArray("status"); len(statuses) > 0 {
+		for _, status := range statuses {
+			valid := false
+			for _, s := range queueStatuses {
+				valid = valid || s == status
+			}
+			if !valid {
+				ctx.JSON(http.StatusBadRequest, errorMessage(
+					"status must be one of pending, in_review, resubmitted"))
+				return
+			}
+		}
+		arg.Statuses = statuses
+	}
+
+	now := time.Now()
+	if s := ctx.Query("min_age_hours"); s != "" {
+		hours, err := strconv.Atoi(s)
+		if err != nil || hours < 0 {
+			ctx.JSON(http.StatusBadRequest, errorMessage("invalid min_age_hours"))
+			return
+		}
+		arg.WaitingBefore = sql.NullTime{Time: now.Add(-time.Duration(hours) * time.Hour), Valid: true}
+	}
+
+	switch ctx.DefaultQuery("sort", "oldest") {
+	case "oldest":
+	case "newest":
+		arg.NewestFirst = true
+	default:
+		ctx.JSON(http.StatusBadRequest, errorMessage("sort must be oldest or newest"))
+		return
+	}
+
+	limit := defaultQueueLimit
+	if s := ctx.Query("limit"); s != "" {
+		limit, err = strconv.Atoi(s)
+		if err != nil || limit < 1 || limit > maxQueueLimit {
+			ctx.JSON(http.StatusBadRequest, errorMessage(
+				fmt.Sprintf("limit must be between 1 and %d", maxQueueLimit)))
+			return
+		}
+	}
+	// one extra row tells whether there is another page
+	arg.PageLimit = int32(limit + 1)
+
+	if cursor := ctx.Query("cursor"); cursor != "" {
+		updatedAt, id, err := decodeQueueCursor(cursor)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		arg.CursorUpdatedAt = sql.NullTime{Time: updatedAt, Valid: true}
+		arg.CursorID = ToNullInt64(id)
+	}
+
+	rows, err := server.store.ListApproverQueue(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	nextCursor := ""
+	if len(rows) > limit {
+		rows = rows[:limit]
+		last := rows[len(rows)-1]
+		nextCursor = encodeQueueCursor(last.WaitingSince, last.RecordID)
+	}
+
+	items := make([]queueItem, 0, len(rows))
+	for _, row := range rows {
+		var onBehalfOf int64
+		if row.ResponsibleStaffID.Valid && row.ResponsibleStaffID.Int64 != payload.UserID {
+			onBehalfOf = row.ResponsibleStaffID.Int64
+		}
+
+		items = append(items, queueItem{
+			RecordID:       row.RecordID,
+			RequestID:      row.RequestID.Int64,
+			SessionID:      row.SessionID,
+			SessionName:    row.SessionName,
+			Status:         row.Status,
+			StudentID:      row.StudentID,
+			StudentNumber:  row.StudentNumber,
+			StudentName:    row.FirstName + " " + row.LastName,
+			DepartmentID:   row.DepartmentID,
+			ItemID:         row.ItemID,
+			ItemTitle:      row.Title,
+			WaitingSince:   row.WaitingSince.Format(time.RFC3339),
+			WaitingSeconds: int64(now.Sub(row.WaitingSince).Seconds()),
+			OnBehalfOf:     onBehalfOf,
+			Claimable:      !row.ResponsibleStaffID.Valid,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"items":       items,
+		"next_cursor": nextCursor,
+	})
+}
@@ -0,0 +1,126 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"strings"
+
+	db "github.com/backendn/clearance_system/db/sqlc"
+	"github.com/gin-gonic/gin"
+)
+
+type createRecordCommentRequest struct {
+	Body string `json:"body" binding:"required"`
+}
+
+// POST /clearance_records/:id/comments
+// Accepts JSON ({"body": ...}) or a multipart form with "body" and an
+// optional "file". The file is listed with the record's attachments but
+// does not satisfy the item's attachment requirement.
+func (server *Server) createRecordComment(ctx *gin.Context) {
+	record, ok := server.viewableRecord(ctx)
+	if !ok {
+		return
+	}
+
+	var body string
+	var header *multipart.FileHeader
+	if strings.HasPrefix(ctx.ContentType(), "multipart/") {
+		header, ok = server.formFile(ctx)
+		if !ok {
+			return
+		}
+		body = ctx.PostForm("body")
+	} else {
+		var req createRecordCommentRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		body = req.Body
+	}
+
+	body = strings.TrimSpace(body)
+	if body == "" {
+		ctx.JSON(http.StatusBadRequest, validationError("body_required",
+			errors.New("comment body is required"), gin.H{"field": "body"}))
+		return
+	}
+
+	var attachment *db.CreateRecordAttachmentParams
+	if header != nil {
+		arg, ok := server.storeRecordFile(ctx, record, header)
+		if !ok {
+			return
+		}
+		// a file sent with a comment does not count as the record's own
+		arg.Source = db.AttachmentSourceComment
+		attachment = &arg
+	}
+
+	payload := authPayload(ctx)
+	var comment db.RecordComment
+	err := server.store.ExecTx(ctx, func(q db.Querier) error {
+		arg := db.CreateRecordCommentParams{
+			RecordID:   record.ID,
+			AuthorRole: payload.Role,
+			AuthorID:   payload.UserID,
+			Body:       body,
+		}
+
+		if attachment != nil {
+			stored, err := q.CreateRecordAttachment(ctx, *attachment)
+			if err != nil {
+				return err
+			}
+			arg.AttachmentID = sql.NullInt64{Int64: stored.ID, Valid: true}
+		}
+
+		var err error
+		comment, err = q.CreateRecordComment(ctx, arg)
+		return err
+	})
+	if err != nil {
+		if attachment != nil {
+			// don't leave orphaned objects behind
+			_ = server.storage.Delete(ctx, attachment.StorageKey)
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	item, err := server.store.GetRecordItem(ctx, record.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// the student hears from the department and the approver from the student
+	msg := fmt.Sprintf("New comment on clearance item '%s': %s", item.Title, body)
+	if payload.Role == "student" {
+		server.sendNotification(ctx, approverToNotify(item.ApproverStaffID, item.AssignedStaffID), 0, msg)
+	} else {
+		server.sendNotification(ctx, 0, record.StudentID, msg)
+	}
+
+	ctx.JSON(http.StatusCreated, comment)
+}
+
+// GET /clearance_records/:id/comments
+func (server *Server) listRecordComments(ctx *gin.Context) {
+	record, ok := server.viewableRecord(ctx)
+	if !ok {
+		return
+	}
+
+	comments, err := server.store.ListRecordComments(ctx, record.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, comments)
+}
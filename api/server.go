@@ -1,21 +1,42 @@
 package api
 
 import (
+	"time"
+
+	"github.com/backendn/clearance_system/certificate"
 	db "github.com/backendn/clearance_system/db/sqlc"
 	middleware "github.com/backendn/clearance_system/middlware"
+	"github.com/backendn/clearance_system/payment"
+	"github.com/backendn/clearance_system/storage"
 	"github.com/backendn/clearance_system/token"
+	"github.com/backendn/clearance_system/util"
 
 	"github.com/gin-gonic/gin"
+	"golang.org/x/time/rate"
+)
+
+// defaultMaxUploadSize is used when MAX_UPLOAD_SIZE is not configured.
+const defaultMaxUploadSize = 10 << 20
+
+// Certificate verification is public, so each client IP may only look up
+// a code every few seconds, with short bursts, to stop codes being guessed.
+const (
+	verifyRateInterval = 6 * time.Second
+	verifyRateBurst    = 5
 )
 
 type Server struct {
+	config     util.Config
 	store      db.Store
 	router     *gin.Engine
 	tokenMaker token.Maker
+	storage    storage.Storage
+	payments   payment.Gateway
+	signer     *certificate.Signer
 }
 
 // NewServer creates a new HTTP server and configures routes
-func NewServer(store db.Store) *Server {
+func NewServer(config util.Config, store db.Store) *Server {
 	secretKey := "your-very-secure-32-char-secret-key"
 
 	maker, err := token.NewJWTMaker(secretKey)
@@ -23,12 +44,40 @@ func NewServer(store db.Store) *Server {
 		panic("cannot create token maker: " + err.Error())
 	}
 
+	fileStorage, err := storage.New(config)
+	if err != nil {
+		panic("cannot create attachment storage: " + err.Error())
+	}
+
+	paymentGateway, err := payment.New(config)
+	if err != nil {
+		panic("cannot create payment gateway: " + err.Error())
+	}
+
+	signer, err := certificate.NewSigner(config.CertificateSigningKey)
+	if err != nil {
+		panic("cannot create certificate signer: " + err.Error())
+	}
+
+	if config.MaxUploadSize <= 0 {
+		config.MaxUploadSize = defaultMaxUploadSize
+	}
+
 	server := &Server{
+		config:     config,
 		store:      store,
 		tokenMaker: maker,
+		storage:    fileStorage,
+		payments:   paymentGateway,
+		signer:     signer,
 	}
 
 	router := gin.Default()
+	// gin trusts every proxy by default, which would let clients pick their
+	// own IP for the rate limits through X-Forwarded-For
+	if err := router.SetTrustedProxies(config.TrustedProxies); err != nil {
+		panic("invalid trusted proxies: " + err.Error())
+	}
 	server.router = router
 
 	server.setupRoutes()
@@ -43,6 +92,10 @@ func (server *Server) setupRoutes() {
 	server.router.POST("/login", server.Login)
 	server.router.POST("/register", server.CreateStaffUser) // only for now
 	server.router.POST("/admins/login", server.LoginAdmin)
+	server.router.POST("/students/login", server.StudentLogin)
+	server.router.POST("/payments/webhook", server.paymentWebhook)
+	server.router.GET("/certificates/public_key", server.getCertificatePublicKey)
+	server.router.GET("/verify/:code", middleware.RateLimit(rate.Every(verifyRateInterval), verifyRateBurst), server.verifyCertificate)
 
 	// --------------------
 	// AUTHENTICATED ROUTES
@@ -68,7 +121,40 @@ func (server *Server) setupRoutes() {
 	admin.DELETE("/roles/:id", server.DeleteRole)
 
 	admin.POST("/clearance_items", server.createClearanceItem)
+	admin.GET("/clearance_items/:id/rules", server.listClearanceItemRules)
+	admin.POST("/clearance_items/:id/rules", server.createClearanceItemRule)
+	admin.DELETE("/clearance_items/:id/rules/:rule_id", server.deleteClearanceItemRule)
+	admin.PUT("/clearance_items/:id/pool", server.setClearanceItemPool)
+	admin.GET("/clearance_items/:id/signatures", server.getItemSignaturePolicy)
+	admin.PUT("/clearance_items/:id/signatures", server.setItemSignaturePolicy)
 	admin.DELETE("/staff_users/:id", server.DeleteStaffUser)
+	admin.PUT("/students/:id/password", server.SetStudentPassword)
+
+	admin.POST("/clearance_types", server.createClearanceType)
+	admin.PUT("/clearance_types/:id", server.updateClearanceType)
+	admin.DELETE("/clearance_types/:id", server.deleteClearanceType)
+
+	admin.POST("/approver_groups", server.createApproverGroup)
+	admin.GET("/approver_groups", server.listApproverGroups)
+	admin.DELETE("/approver_groups/:id", server.deleteApproverGroup)
+	admin.GET("/approver_groups/:id/members", server.listApproverGroupMembers)
+	admin.POST("/approver_groups/:id/members", server.addApproverGroupMember)
+	admin.DELETE("/approver_groups/:id/members/:staff_id", server.removeApproverGroupMember)
+
+	admin.POST("/delegations", server.adminCreateDelegation)
+	admin.DELETE("/delegations/:id", server.deleteDelegation)
+
+	admin.POST("/sessions", server.createSession)
+	admin.GET("/sessions", server.listSessions)
+	admin.GET("/sessions/:id", server.getSession)
+	admin.PUT("/sessions/:id", server.updateSession)
+	admin.POST("/sessions/:id/activate", server.activateSession)
+	admin.POST("/sessions/:id/deactivate", server.deactivateSession)
+	admin.DELETE("/sessions/:id", server.deleteSession)
+	admin.GET("/sessions/:id/items", server.listSessionItems)
+	admin.POST("/sessions/:id/items", server.upsertSessionItem)
+	admin.POST("/sessions/:id/items/copy", server.copySessionItems)
+	admin.DELETE("/sessions/:id/items/:item_id", server.deleteSessionItem)
 
 	// --------------------
 	// STAFF ONLY
@@ -77,7 +163,19 @@ func (server *Server) setupRoutes() {
 	staff.Use(middleware.RoleMiddleware("staff", "admin"))
 
 	staff.PATCH("/clearance_records/:id/status", server.updateClearanceRecordStatus)
+	staff.POST("/clearance_records/bulk_status", server.bulkUpdateClearanceRecordStatus)
 	staff.GET("/sessions/:session_id/records", server.listRecordsBySession)
+	staff.GET("/clearance_records/missing_attachments", server.listRecordsMissingAttachments)
+	staff.POST("/clearance_records/:id/claim", middleware.RoleMiddleware("staff"), server.claimClearanceRecord)
+	staff.POST("/clearance_records/:id/release", middleware.RoleMiddleware("staff"), server.releaseClearanceRecord)
+	staff.POST("/clearance_records/:id/assign", server.assignClearanceRecord)
+	staff.GET("/me/queue", middleware.RoleMiddleware("staff"), server.getApproverQueue)
+	staff.POST("/me/delegations", middleware.RoleMiddleware("staff"), server.createDelegation)
+	staff.GET("/me/delegations", middleware.RoleMiddleware("staff"), server.listMyDelegations)
+	staff.DELETE("/me/delegations/:id", middleware.RoleMiddleware("staff"), server.deleteDelegation)
+	staff.POST("/obligations", server.raiseObligation)
+	staff.GET("/obligations", server.listObligations)
+	staff.POST("/obligations/:id/resolve", server.resolveObligation)
 
 	// --------------------
 	// STUDENT ONLY
@@ -85,50 +183,76 @@ func (server *Server) setupRoutes() {
 	student := auth.Group("/")
 	student.Use(middleware.RoleMiddleware("student"))
 
-	student.POST("/students/:id/clearance_request", server.SubmitClearanceRequest)
-	student.GET("/students/:id/clearance_requests", server.ListStudentRequests)
+	student.POST("/students/:id/clearance_request", middleware.SelfOrRoles("id", "student"), server.SubmitClearanceRequest)
+	student.GET("/students/:id/clearance_requests", middleware.SelfOrRoles("id", "student"), server.ListStudentRequests)
+	student.POST("/clearance_records/:id/submit", server.submitClearanceRecord)
+	student.POST("/clearance_records/:id/resubmit", server.resubmitClearanceRecord)
+	student.POST("/obligations/:id/payments", server.createObligationPayment)
 
 	// --------------------
 	// GENERAL AUTH ROUTES (everyone with login)
 	// --------------------
 
 	// Students
-	auth.POST("/students", server.CreateStudent)
-	auth.GET("/students/number/:student_number", server.GetStudentByNumber)
-	auth.PATCH("/students/:id", server.UpdateStudent)
-	auth.DELETE("/students/:id", server.DeleteStudent)
+	// student accounts are managed by admins; passwords are only set
+	// through PUT /admins/students/:id/password
+	auth.POST("/students", middleware.AdminOnly(), server.CreateStudent)
+	auth.GET("/students/number/:student_number", middleware.RoleMiddleware("staff", "admin"), server.GetStudentByNumber)
+	auth.PATCH("/students/:id", middleware.AdminOnly(), server.UpdateStudent)
+	auth.DELETE("/students/:id", middleware.AdminOnly(), server.DeleteStudent)
+	auth.GET("/students/:id/obligations", middleware.SelfOrRoles("id", "student", "staff", "admin"), server.listStudentObligations)
+	auth.GET("/students/:id/payments", middleware.SelfOrRoles("id", "student", "staff", "admin"), server.listStudentPayments)
 
 	// Departments
 	auth.GET("/departments", server.ListDepartments)
 	auth.GET("/departments/:id", server.GetDepartment)
-	auth.PATCH("/departments/:id", server.UpdateDepartment)
+	auth.PATCH("/departments/:id", middleware.AdminOnly(), server.UpdateDepartment)
 
 	// Staff
 	auth.GET("/staff_users/:id", server.GetStaffUser)
 	auth.GET("/staff_users", server.ListStaffUsers)
-	auth.PATCH("/staff_users/:id", server.UpdateStaffUser)
+	auth.PATCH("/staff_users/:id", middleware.AdminOnly(), server.UpdateStaffUser)
 
 	// Clearance Items
 	auth.GET("/clearance_items", server.listClearanceItems)
 	auth.GET("/clearance_items/:id", server.getClearanceItem)
+	auth.GET("/clearance_items/:id/prerequisites", server.listItemPrerequisites)
 	auth.GET("/departments/department/:department_id/clearance-items", server.listItemsByDepartment)
-	auth.PATCH("/clearance_items/:id", server.updateClearanceItem)
-	auth.DELETE("/clearance_items/:id", server.deleteClearanceItem)
+	auth.PATCH("/clearance_items/:id", middleware.AdminOnly(), server.updateClearanceItem)
+	auth.DELETE("/clearance_items/:id", middleware.AdminOnly(), server.deleteClearanceItem)
+
+	// Clearance Types
+	auth.GET("/clearance_types", server.listClearanceTypes)
+	auth.GET("/clearance_types/:id", server.getClearanceType)
 
 	// Clearance Requests
 	auth.GET("/clearance_requests/:id", server.GetClearanceRequest)
+	auth.GET("/clearance_requests/:id/graph", server.getClearanceRequestGraph)
+	auth.GET("/clearance_requests/:id/certificate", server.downloadCertificate)
 
-	// Records
-	auth.POST("/clearance_records", server.createClearanceRecord)
+	// Records; creating one by hand bypasses the request workflow, so only
+	// admins may. Records are never deleted: their status history is kept
+	auth.POST("/clearance_records", middleware.AdminOnly(), server.createClearanceRecord)
 	auth.GET("/clearance_records/:id", server.getClearanceRecord)
-	auth.GET("/students/student/:student_id/records", server.listRecordsByStudent)
-	auth.DELETE("/clearance_records/:id", server.deleteClearanceRecord)
-
-	// Notifications
-	auth.POST("/notifications", server.CreateNotification)
+	auth.GET("/clearance_records/:id/history", server.getClearanceRecordHistory)
+	auth.GET("/clearance_records/:id/signatures", server.listRecordSignatures)
+	auth.GET("/students/student/:student_id/records", middleware.SelfOrRoles("student_id", "student", "staff", "admin"), server.listRecordsByStudent)
+
+	// Attachments
+	auth.POST("/clearance_records/:id/attachments", server.uploadRecordAttachment)
+	auth.GET("/clearance_records/:id/attachments", server.listRecordAttachments)
+	auth.GET("/clearance_records/:id/attachments/:attachment_id", server.downloadRecordAttachment)
+	auth.DELETE("/clearance_records/:id/attachments/:attachment_id", server.deleteRecordAttachment)
+
+	// Comments
+	auth.POST("/clearance_records/:id/comments", server.createRecordComment)
+	auth.GET("/clearance_records/:id/comments", server.listRecordComments)
+
+	// Notifications; the handlers check that the caller is the recipient
+	auth.POST("/notifications", middleware.RoleMiddleware("staff", "admin"), server.CreateNotification)
 	auth.GET("/notifications/:id", server.GetNotification)
-	auth.GET("/notifications/user/:id", server.ListNotificationsForUser)
-	auth.GET("/notifications/student/:id", server.ListNotificationsForStudent)
+	auth.GET("/notifications/user/:id", middleware.SelfOrRoles("id", "staff", "admin"), server.ListNotificationsForUser)
+	auth.GET("/notifications/student/:id", middleware.SelfOrRoles("id", "student", "admin"), server.ListNotificationsForStudent)
 	auth.PATCH("/notifications/:id/read", server.MarkNotificationRead)
 	auth.DELETE("/notifications/:id", server.DeleteNotification)
 
@@ -0,0 +1,60 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	db "github.com/backendn/clearance_system/db/sqlc"
+	"github.com/backendn/clearance_system/util"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T, config util.Config, store db.Store) *Server {
+	gin.SetMode(gin.TestMode)
+	config.StorageLocalDir = t.TempDir()
+	config.PaymentProvider = "fake"
+	config.PaymentFakeEnabled = true
+	config.PaymentWebhookSecret = "test-webhook-secret"
+	config.CertificateSigningKey = "BwcHBwcHBwcHBwcHBwcHBwcHBwcHBwcHBwcHBwcHBwc="
+	return NewServer(config, store)
+}
+
+func TestVerifyRateLimitIgnoresSpoofedForwardedFor(t *testing.T) {
+	server := newTestServer(t, util.Config{}, nil)
+	// too long to be a code, so the lookup never reaches the store
+	path := "/verify/" + strings.Repeat("A", 40)
+
+	codes := make([]int, 0, verifyRateBurst+1)
+	for i := 0; i <= verifyRateBurst; i++ {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.RemoteAddr = "203.0.113.7:40000"
+		req.Header.Set("X-Forwarded-For", fmt.Sprintf("198.51.100.%d", i))
+		recorder := httptest.NewRecorder()
+		server.router.ServeHTTP(recorder, req)
+		codes = append(codes, recorder.Code)
+	}
+
+	for _, code := range codes[:verifyRateBurst] {
+		require.Equal(t, http.StatusNotFound, code)
+	}
+	require.Equal(t, http.StatusTooManyRequests, codes[verifyRateBurst])
+}
+
+func TestVerifyRateLimitUsesTrustedProxyHeader(t *testing.T) {
+	server := newTestServer(t, util.Config{TrustedProxies: []string{"203.0.113.7"}}, nil)
+	path := "/verify/" + strings.Repeat("A", 40)
+
+	// behind a trusted proxy each forwarded client has its own limit
+	for i := 0; i <= verifyRateBurst; i++ {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.RemoteAddr = "203.0.113.7:40000"
+		req.Header.Set("X-Forwarded-For", fmt.Sprintf("198.51.100.%d", i))
+		recorder := httptest.NewRecorder()
+		server.router.ServeHTTP(recorder, req)
+		require.Equal(t, http.StatusNotFound, recorder.Code)
+	}
+}
@@ -0,0 +1,187 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+
+	db "github.com/backendn/clearance_system/db/sqlc"
+	"github.com/gin-gonic/gin"
+)
+
+type upsertSessionItemRequest struct {
+	ClearanceItemID int64 `json:"clearance_item_id" binding:"required,min=1"`
+	// Optional overrides; when omitted the item's own values are used.
+	Sequence        int32 `json:"sequence" binding:"omitempty,min=1"`
+	ApproverStaffID int64 `json:"approver_staff_id" binding:"omitempty,min=1"`
+}
+
+type copySessionItemsRequest struct {
+	FromSessionID int64 `json:"from_session_id" binding:"required,min=1"`
+}
+
+// GET /admins/sessions/:id/items
+func (server *Server) listSessionItems(ctx *gin.Context) {
+	sessionID, err := getIDParam(ctx)
+	if err != nil {
+		return
+	}
+
+	if _, err := server.store.GetSession(ctx, sessionID); err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorMessage("clearance session not found"))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// Sessions without their own item set fall back to every clearance item.
+	items, err := db.SessionClearanceItems(ctx, server.store, sessionID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, items)
+}
+
+// POST /admins/sessions/:id/items
+func (server *Server) upsertSessionItem(ctx *gin.Context) {
+	sessionID, err := getIDParam(ctx)
+	if err != nil {
+		return
+	}
+
+	var req upsertSessionItemRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if _, err := server.store.GetSession(ctx, sessionID); err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorMessage("clearance session not found"))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if _, err := server.store.GetClearanceItem(ctx, req.ClearanceItemID); err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusBadRequest, errorMessage("invalid clearance item ID"))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if req.ApproverStaffID != 0 {
+		if _, err := server.store.GetStaffUser(ctx, req.ApproverStaffID); err != nil {
+			if err == sql.ErrNoRows {
+				ctx.JSON(http.StatusBadRequest, errorMessage("invalid approver staff ID"))
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+	}
+
+	item, err := server.store.UpsertSessionItem(ctx, db.UpsertSessionItemParams{
+		SessionID:       sessionID,
+		ClearanceItemID: req.ClearanceItemID,
+		Sequence:        sql.NullInt32{Int32: req.Sequence, Valid: req.Sequence != 0},
+		ApproverStaffID: ToNullInt64(req.ApproverStaffID),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, item)
+}
+
+// DELETE /admins/sessions/:id/items/:item_id
+func (server *Server) deleteSessionItem(ctx *gin.Context) {
+	sessionID, err := getIDParam(ctx)
+	if err != nil {
+		return
+	}
+
+	itemID, err := strconv.ParseInt(ctx.Param("item_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorMessage("invalid item_id parameter"))
+		return
+	}
+
+	err = server.store.DeleteSessionItem(ctx, db.DeleteSessionItemParams{
+		SessionID:       sessionID,
+		ClearanceItemID: itemID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "deleted"})
+}
+
+// POST /admins/sessions/:id/items/copy
+func (server *Server) copySessionItems(ctx *gin.Context) {
+	sessionID, err := getIDParam(ctx)
+	if err != nil {
+		return
+	}
+
+	var req copySessionItemsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if req.FromSessionID == sessionID {
+		ctx.JSON(http.StatusBadRequest, errorMessage("cannot copy a session's items into itself"))
+		return
+	}
+
+	if _, err := server.store.GetSession(ctx, sessionID); err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorMessage("clearance session not found"))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if _, err := server.store.GetSession(ctx, req.FromSessionID); err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusBadRequest, errorMessage("invalid from_session_id"))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	count, err := server.store.CountSessionItems(ctx, req.FromSessionID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if count == 0 {
+		ctx.JSON(http.StatusBadRequest, errorMessage("source session has no item set to copy"))
+		return
+	}
+
+	// Items already configured on the target session are kept as they are.
+	copied, err := server.store.CopySessionItems(ctx, db.CopySessionItemsParams{
+		SessionID:     sessionID,
+		FromSessionID: req.FromSessionID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"copied": copied})
+}
@@ -0,0 +1,169 @@
+package api
+
+import (
+	"net/http"
+
+	db "github.com/backendn/clearance_system/db/sqlc"
+	"github.com/gin-gonic/gin"
+)
+
+type itemSignerRequest struct {
+	StaffID int64 `json:"staff_id" binding:"omitempty,min=1"`
+	RoleID  int64 `json:"role_id" binding:"omitempty,min=1"`
+}
+
+type itemSignaturePolicyRequest struct {
+	RequiredSignatures int32               `json:"required_signatures" binding:"required,min=1"`
+	RejectionPolicy    string              `json:"rejection_policy" binding:"omitempty,oneof=block reset"`
+	Signers            []itemSignerRequest `json:"signers" binding:"dive"`
+}
+
+// GET /admins/clearance_items/:id/signatures
+func (s *Server) getItemSignaturePolicy(ctx *gin.Context) {
+	itemID, err := getIDParam(ctx)
+	if err != nil {
+		return
+	}
+
+	item, err := s.store.GetClearanceItem(ctx, itemID)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, errorMessage("clearance item not found"))
+		return
+	}
+
+	signers, err := s.store.ListClearanceItemSigners(ctx, itemID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"required_signatures": item.RequiredSignatures,
+		"rejection_policy":    item.RejectionPolicy,
+		"signers":             signers,
+	})
+}
+
+// PUT /admins/clearance_items/:id/signatures
+// Sets how many distinct signers must approve the item and replaces the
+// set of staff users and roles allowed to sign.
+func (s *Server) setItemSignaturePolicy(ctx *gin.Context) {
+	itemID, err := getIDParam(ctx)
+	if err != nil {
+		return
+	}
+
+	var req itemSignaturePolicyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if req.RejectionPolicy == "" {
+		req.RejectionPolicy = db.RejectionPolicyBlock
+	}
+
+	if _, err := s.store.GetClearanceItem(ctx, itemID); err != nil {
+		ctx.JSON(http.StatusNotFound, errorMessage("clearance item not found"))
+		return
+	}
+
+	// a role may have any number of members, so only named staff are counted
+	staffSigners := map[int64]bool{}
+	hasRole := false
+	for _, signer := range req.Signers {
+		if (signer.StaffID == 0) == (signer.RoleID == 0) {
+			ctx.JSON(http.StatusBadRequest, errorMessage("each signer needs exactly one of staff_id or role_id"))
+			return
+		}
+		if signer.StaffID != 0 {
+			if _, err := s.store.GetStaffUser(ctx, signer.StaffID); err != nil {
+				ctx.JSON(http.StatusBadRequest, errorMessage("invalid staff ID"))
+				return
+			}
+			staffSigners[signer.StaffID] = true
+			continue
+		}
+		if _, err := s.store.GetRole(ctx, signer.RoleID); err != nil {
+			ctx.JSON(http.StatusBadRequest, errorMessage("invalid role ID"))
+			return
+		}
+		hasRole = true
+	}
+	if req.RequiredSignatures > 1 && !hasRole && int32(len(staffSigners)) < req.RequiredSignatures {
+		ctx.JSON(http.StatusBadRequest, errorMessage("there are fewer signers than required signatures"))
+		return
+	}
+
+	var item db.ClearanceItem
+	var signers []db.ClearanceItemSigner
+	err = s.store.ExecTx(ctx, func(q db.Querier) error {
+		var err error
+		item, err = q.SetClearanceItemSignaturePolicy(ctx, db.SetClearanceItemSignaturePolicyParams{
+			ID:                 itemID,
+			RequiredSignatures: req.RequiredSignatures,
+			RejectionPolicy:    req.RejectionPolicy,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := q.DeleteClearanceItemSigners(ctx, itemID); err != nil {
+			return err
+		}
+
+		signers = make([]db.ClearanceItemSigner, 0, len(req.Signers))
+		for _, signer := range req.Signers {
+			created, err := q.CreateClearanceItemSigner(ctx, db.CreateClearanceItemSignerParams{
+				ClearanceItemID: itemID,
+				StaffID:         ToNullInt64(signer.StaffID),
+				RoleID:          ToNullInt64(signer.RoleID),
+			})
+			if err != nil {
+				return err
+			}
+			signers = append(signers, created)
+		}
+		return nil
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"required_signatures": item.RequiredSignatures,
+		"rejection_policy":    item.RejectionPolicy,
+		"signers":             signers,
+	})
+}
+
+// GET /clearance_records/:id/signatures
+// Returns every signature given on the record, including the ones that were
+// replaced or reset, and how far the record is from approval.
+func (s *Server) listRecordSignatures(ctx *gin.Context) {
+	record, ok := s.viewableRecord(ctx)
+	if !ok {
+		return
+	}
+
+	item, err := s.store.GetRecordItem(ctx, record.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	signatures, err := s.store.ListRecordSignatures(ctx, record.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	approvals, rejections := db.CountSignatures(signatures)
+	ctx.JSON(http.StatusOK, gin.H{
+		"required_signatures": item.RequiredSignatures,
+		"rejection_policy":    item.RejectionPolicy,
+		"approvals":           approvals,
+		"rejections":          rejections,
+		"signatures":          signatures,
+	})
+}
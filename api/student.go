@@ -1,10 +1,12 @@
 package api
 
 import (
+	"context"
 	"net/http"
 	"strconv"
 
 	sqlc "github.com/backendn/clearance_system/db/sqlc"
+	"github.com/backendn/clearance_system/util"
 	"github.com/gin-gonic/gin"
 )
 
@@ -19,6 +21,11 @@ type CreateStudentRequest struct {
 	EnrollmentYear int32  `json:"enrollment_year" binding:"required"`
 }
 
+// SetStudentPasswordRequest is the expected body for setting a student's password
+type SetStudentPasswordRequest struct {
+	Password string `json:"password" binding:"required,min=6"`
+}
+
 // CreateStudent handler
 func (server *Server) CreateStudent(ctx *gin.Context) {
 	var req CreateStudentRequest
@@ -106,6 +113,47 @@ func (server *Server) UpdateStudent(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, student)
 }
 
+// SetStudentPassword handler (admin): sets or resets a student's login password
+func (server *Server) SetStudentPassword(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var req SetStudentPasswordRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if _, err := server.store.GetStudent(ctx, id); err != nil {
+		ctx.JSON(http.StatusNotFound, errorMessage("student not found"))
+		return
+	}
+
+	if err := setStudentPassword(ctx, server.store, id, req.Password); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "password updated"})
+}
+
+// setStudentPassword hashes and stores a student's password.
+func setStudentPassword(ctx context.Context, q sqlc.Querier, studentID int64, password string) error {
+	hashed, err := util.HashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	_, err = q.UpsertStudentCredential(ctx, sqlc.UpsertStudentCredentialParams{
+		StudentID:      studentID,
+		HashedPassword: hashed,
+	})
+	return err
+}
+
 // DeleteStudent handler
 func (server *Server) DeleteStudent(ctx *gin.Context) {
 	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
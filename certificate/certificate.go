@@ -0,0 +1,144 @@
+package certificate
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Item is one clearance item listed on a certificate.
+type Item struct {
+	Title      string    `json:"title"`
+	Department string    `json:"department"`
+	Status     string    `json:"status"` // approved or waived
+	Approver   string    `json:"approver"`
+	ApprovedAt time.Time `json:"approved_at"`
+}
+
+// Certificate holds everything printed on a clearance certificate. Its
+// JSON encoding is what gets signed.
+type Certificate struct {
+	Number        string    `json:"number"`
+	StudentName   string    `json:"student_name"`
+	StudentNumber string    `json:"student_number"`
+	Session       string    `json:"session"`
+	ClearanceType string    `json:"clearance_type"`
+	ClearedAt     time.Time `json:"cleared_at"`
+	IssuedAt      time.Time `json:"issued_at"`
+	Items         []Item    `json:"items"`
+	// VerificationCode looks the certificate up at the public verification
+	// endpoint. Certificates issued before codes existed have none.
+	VerificationCode string `json:"verification_code,omitempty"`
+}
+
+// Payload returns the canonical bytes of a certificate that are signed.
+// Times are stored in UTC with second precision so the payload reads the
+// same wherever it is produced.
+func Payload(c Certificate) ([]byte, error) {
+	c.ClearedAt = c.ClearedAt.UTC().Truncate(time.Second)
+	c.IssuedAt = c.IssuedAt.UTC().Truncate(time.Second)
+	items := make([]Item, len(c.Items))
+	for i, item := range c.Items {
+		item.ApprovedAt = item.ApprovedAt.UTC().Truncate(time.Second)
+		items[i] = item
+	}
+	c.Items = items
+	return json.Marshal(c)
+}
+
+// Signer signs certificates with the server's Ed25519 key.
+type Signer struct {
+	key ed25519.PrivateKey
+}
+
+// publishedSeeds are signing keys that were committed to the repository.
+// Anyone can forge certificates with them, so they are never accepted.
+var publishedSeeds = map[string]bool{
+	"CVDLPnn0gxvtA71ueGJSxEwBA1dMs0Te31cXjtwrzoM=": true,
+}
+
+// NewSigner creates a signer from a base64 encoded 32 byte Ed25519 seed,
+// as produced by `openssl rand -base64 32`.
+func NewSigner(seed string) (*Signer, error) {
+	if seed == "" {
+		return nil, errors.New("certificate signing key is required; generate one with `openssl rand -base64 32`")
+	}
+	if publishedSeeds[seed] {
+		return nil, errors.New("certificate signing key is a published example key; generate a new one")
+	}
+	raw, err := base64.StdEncoding.DecodeString(seed)
+	if err != nil {
+		return nil, fmt.Errorf("certificate signing key is not valid base64: %w", err)
+	}
+	if len(raw) != ed25519.SeedSize {
+		return nil, fmt.Errorf("certificate signing key must be a %d byte seed", ed25519.SeedSize)
+	}
+	return &Signer{key: ed25519.NewKeyFromSeed(raw)}, nil
+}
+
+// PublicKey returns the key that verifies the signer's signatures.
+func (s *Signer) PublicKey() ed25519.PublicKey {
+	return s.key.Public().(ed25519.PublicKey)
+}
+
+// KeyID identifies the signing key so signatures made with a retired key
+// can be told apart after a rotation.
+func (s *Signer) KeyID() string {
+	return KeyID(s.PublicKey())
+}
+
+// Sign returns the signature of a payload.
+func (s *Signer) Sign(payload []byte) []byte {
+	return ed25519.Sign(s.key, payload)
+}
+
+// KeyID returns the first 8 bytes of the SHA-256 of a public key in hex.
+func KeyID(key ed25519.PublicKey) string {
+	sum := sha256.Sum256(key)
+	return hex.EncodeToString(sum[:8])
+}
+
+// Verify reports whether signature is a valid signature of payload by key.
+func Verify(key ed25519.PublicKey, payload, signature []byte) bool {
+	return len(key) == ed25519.PublicKeySize && ed25519.Verify(key, payload, signature)
+}
+
+// verificationCodeBytes gives codes of 16 base32 characters, 80 random bits.
+const verificationCodeBytes = 10
+
+// NewVerificationCode returns a random code for looking a certificate up.
+func NewVerificationCode() (string, error) {
+	raw := make([]byte, verificationCodeBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(raw), nil
+}
+
+// NormalizeVerificationCode undoes the grouping and lower casing people
+// apply when typing a code from a printed certificate.
+func NormalizeVerificationCode(code string) string {
+	code = strings.ToUpper(code)
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
+
+// FormatVerificationCode groups a code in blocks of four for printing,
+// e.g. "ABCD-EFGH-IJKL-MNOP".
+func FormatVerificationCode(code string) string {
+	var b strings.Builder
+	for i, r := range code {
+		if i > 0 && i%4 == 0 {
+			b.WriteByte('-')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,97 @@
+package certificate
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testSigner(t *testing.T) *Signer {
+	seed := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{7}, ed25519.SeedSize))
+	signer, err := NewSigner(seed)
+	require.NoError(t, err)
+	return signer
+}
+
+func testCertificate() Certificate {
+	cleared := time.Date(2025, 12, 20, 14, 30, 0, 0, time.UTC)
+	return Certificate{
+		Number:        "CLR-2025-42",
+		StudentName:   "Abebe Bekele",
+		StudentNumber: "UGR/1234/14",
+		Session:       "2025 Graduation",
+		ClearanceType: "Graduation",
+		ClearedAt:     cleared,
+		IssuedAt:      cleared.Add(time.Minute),
+		Items: []Item{
+			{Title: "Library", Department: "Library", Status: "approved", Approver: "Hana Tesfaye", ApprovedAt: cleared},
+			{Title: "Dormitory key return", Department: "Student Housing", Status: "waived", Approver: "José Álvarez", ApprovedAt: cleared},
+		},
+	}
+}
+
+func TestSignAndVerify(t *testing.T) {
+	signer := testSigner(t)
+
+	payload, err := Payload(testCertificate())
+	require.NoError(t, err)
+	signature := signer.Sign(payload)
+	require.True(t, Verify(signer.PublicKey(), payload, signature))
+
+	// the same certificate in another time zone signs the same bytes
+	c := testCertificate()
+	c.ClearedAt = c.ClearedAt.In(time.FixedZone("EAT", 3*60*60))
+	again, err := Payload(c)
+	require.NoError(t, err)
+	require.Equal(t, payload, again)
+
+	c.StudentName = "Someone Else"
+	tampered, err := Payload(c)
+	require.NoError(t, err)
+	require.False(t, Verify(signer.PublicKey(), tampered, signature))
+
+	_, err = NewSigner("c2hvcnQ=")
+	require.Error(t, err)
+
+	// the key once committed to app.env must not sign anything
+	_, err = NewSigner("CVDLPnn0gxvtA71ueGJSxEwBA1dMs0Te31cXjtwrzoM=")
+	require.Error(t, err)
+}
+
+func TestRender(t *testing.T) {
+	signer := testSigner(t)
+	payload, err := Payload(testCertificate())
+	require.NoError(t, err)
+
+	pdf, err := Render(testCertificate(), signer.Sign(payload), signer.KeyID(), "")
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(pdf, []byte("%PDF-")))
+
+	c := testCertificate()
+	c.VerificationCode, err = NewVerificationCode()
+	require.NoError(t, err)
+	withQR, err := Render(c, signer.Sign(payload), signer.KeyID(), "https://example.edu/verify/"+c.VerificationCode)
+	require.NoError(t, err)
+	require.Greater(t, len(withQR), len(pdf))
+}
+
+func TestVerificationCode(t *testing.T) {
+	code, err := NewVerificationCode()
+	require.NoError(t, err)
+	require.Len(t, code, 16)
+
+	other, err := NewVerificationCode()
+	require.NoError(t, err)
+	require.NotEqual(t, code, other)
+
+	printed := FormatVerificationCode(code)
+	require.Len(t, printed, 19)
+	require.Equal(t, code, NormalizeVerificationCode(strings.ToLower(printed)))
+	require.Equal(t, "ABCD-EFGH", FormatVerificationCode("ABCDEFGH"))
+	require.Equal(t, "ABCDEFGH", NormalizeVerificationCode(" abcd efgh "))
+}
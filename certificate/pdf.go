@@ -0,0 +1,137 @@
+package certificate
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"math"
+
+	"github.com/jung-kurt/gofpdf"
+	"github.com/skip2/go-qrcode"
+)
+
+const dateLayout = "2 January 2006"
+
+// qrSize is the printed width and height of the verification QR code in mm.
+const qrSize = 32
+
+// Render lays out a certificate as an A4 PDF. The signature and the ID of
+// the key that made it are printed at the bottom so a printed copy can be
+// checked against the payload. When verifyURL is set it is printed with
+// the verification code and encoded in a QR code.
+func Render(c Certificate, signature []byte, keyID, verifyURL string) ([]byte, error) {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetTitle("Clearance certificate "+c.Number, true)
+	pdf.SetMargins(20, 20, 20)
+	pdf.SetAutoPageBreak(true, 25)
+	// the core fonts are cp1252; translate so accented names print correctly
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.AddPage()
+
+	pdf.SetFont("Helvetica", "B", 20)
+	pdf.CellFormat(0, 12, "Clearance Certificate", "", 1, "C", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.CellFormat(0, 6, tr("Certificate No. "+c.Number), "", 1, "C", false, 0, "")
+	pdf.Ln(8)
+
+	pdf.SetFont("Helvetica", "", 12)
+	pdf.MultiCell(0, 7, tr(fmt.Sprintf(
+		"This is to certify that %s (student number %s) has completed the %s clearance for the %s session. "+
+			"The request was fully cleared on %s.",
+		c.StudentName, c.StudentNumber, c.ClearanceType, c.Session, c.ClearedAt.Format(dateLayout))), "", "L", false)
+	pdf.Ln(6)
+
+	widths := []float64{55, 40, 22, 33, 20}
+	header := []string{"Clearance item", "Department", "Status", "Approver", "Date"}
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.SetFillColor(230, 230, 230)
+	for i, h := range header {
+		pdf.CellFormat(widths[i], 8, h, "1", 0, "L", true, 0, "")
+	}
+	pdf.Ln(-1)
+
+	pdf.SetFont("Helvetica", "", 9)
+	for _, item := range c.Items {
+		row := []string{
+			item.Title,
+			item.Department,
+			item.Status,
+			item.Approver,
+			item.ApprovedAt.Format("2006-01-02"),
+		}
+		for i, cell := range row {
+			pdf.CellFormat(widths[i], 7, fit(pdf, tr(cell), widths[i]-2), "1", 0, "L", false, 0, "")
+		}
+		pdf.Ln(-1)
+	}
+	pdf.Ln(10)
+
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.CellFormat(0, 6, "Issued on "+c.IssuedAt.Format(dateLayout), "", 1, "L", false, 0, "")
+	pdf.Ln(4)
+
+	if c.VerificationCode != "" {
+		if err := addVerification(pdf, c.VerificationCode, verifyURL); err != nil {
+			return nil, err
+		}
+	}
+
+	pdf.SetFont("Courier", "", 7)
+	pdf.MultiCell(0, 4, fmt.Sprintf("Ed25519 signature (key %s):\n%s",
+		keyID, base64.StdEncoding.EncodeToString(signature)), "", "L", false)
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// addVerification prints the verification code, and the URL to check it
+// at as text and as a QR code next to it.
+func addVerification(pdf *gofpdf.Fpdf, code, verifyURL string) error {
+	text := "Verification code: " + FormatVerificationCode(code)
+	if verifyURL == "" {
+		pdf.SetFont("Helvetica", "B", 10)
+		pdf.CellFormat(0, 6, text, "", 1, "L", false, 0, "")
+		pdf.Ln(4)
+		return nil
+	}
+
+	png, err := qrcode.Encode(verifyURL, qrcode.Medium, 256)
+	if err != nil {
+		return err
+	}
+	options := gofpdf.ImageOptions{ImageType: "PNG"}
+	pdf.RegisterImageOptionsReader("verification-qr", options, bytes.NewReader(png))
+
+	pageWidth, pageHeight := pdf.GetPageSize()
+	left, _, right, bottom := pdf.GetMargins()
+	// keep the code and its QR code together on one page
+	if pdf.GetY()+qrSize > pageHeight-bottom {
+		pdf.AddPage()
+	}
+	top := pdf.GetY()
+	pdf.ImageOptions("verification-qr", pageWidth-right-qrSize, top, qrSize, qrSize, false, options, 0, "")
+
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.MultiCell(pageWidth-left-right-qrSize-5, 6, text, "", "L", false)
+	pdf.SetFont("Helvetica", "", 9)
+	pdf.MultiCell(pageWidth-left-right-qrSize-5, 5,
+		"Scan the QR code or visit the address below to check that this certificate is genuine and has not been revoked:\n"+verifyURL,
+		"", "L", false)
+	pdf.SetY(math.Max(pdf.GetY(), top+qrSize) + 4)
+	return pdf.Error()
+}
+
+// fit shortens text with an ellipsis until it fits into width. The text
+// is already cp1252, one byte per character.
+func fit(pdf *gofpdf.Fpdf, text string, width float64) string {
+	if pdf.GetStringWidth(text) <= width {
+		return text
+	}
+	for len(text) > 0 && pdf.GetStringWidth(text+"...") > width {
+		text = text[:len(text)-1]
+	}
+	return text + "..."
+}
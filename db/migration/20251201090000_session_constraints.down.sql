@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS clearance_sessions_single_active;
+
+ALTER TABLE clearance_sessions
+  DROP CONSTRAINT IF EXISTS clearance_sessions_date_range;
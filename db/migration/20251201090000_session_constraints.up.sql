@@ -0,0 +1,7 @@
+ALTER TABLE clearance_sessions
+  ADD CONSTRAINT clearance_sessions_date_range CHECK (end_date >= start_date);
+
+-- at most one session may be active at a time
+CREATE UNIQUE INDEX clearance_sessions_single_active
+  ON clearance_sessions (active)
+  WHERE active;
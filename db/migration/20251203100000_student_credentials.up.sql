@@ -0,0 +1,8 @@
+-- ============================
+--     STUDENT CREDENTIALS
+-- ============================
+CREATE TABLE student_credentials (
+  student_id BIGINT PRIMARY KEY REFERENCES students(id) ON DELETE CASCADE,
+  hashed_password TEXT NOT NULL,
+  updated_at timestamptz NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,8 @@
+ALTER TABLE clearance_records
+  DROP CONSTRAINT IF EXISTS clearance_records_student_item_session_key;
+
+ALTER TABLE clearance_requests
+  DROP CONSTRAINT IF EXISTS clearance_requests_student_session_key;
+
+ALTER TABLE clearance_records
+  ALTER COLUMN handled_by SET NOT NULL;
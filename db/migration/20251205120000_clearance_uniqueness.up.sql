@@ -0,0 +1,9 @@
+-- records are created before anybody has handled them
+ALTER TABLE clearance_records
+  ALTER COLUMN handled_by DROP NOT NULL;
+
+ALTER TABLE clearance_requests
+  ADD CONSTRAINT clearance_requests_student_session_key UNIQUE (student_id, session_id);
+
+ALTER TABLE clearance_records
+  ADD CONSTRAINT clearance_records_student_item_session_key UNIQUE (student_id, clearance_item_id, session_id);
@@ -0,0 +1,5 @@
+ALTER TABLE clearance_records
+  DROP CONSTRAINT IF EXISTS clearance_records_status_check;
+
+ALTER TABLE clearance_records
+  ALTER COLUMN status DROP DEFAULT;
@@ -0,0 +1,10 @@
+UPDATE clearance_records
+SET status = 'pending'
+WHERE status NOT IN ('pending', 'in_review', 'approved', 'rejected', 'resubmitted', 'waived');
+
+ALTER TABLE clearance_records
+  ALTER COLUMN status SET DEFAULT 'pending';
+
+ALTER TABLE clearance_records
+  ADD CONSTRAINT clearance_records_status_check
+  CHECK (status IN ('pending', 'in_review', 'approved', 'rejected', 'resubmitted', 'waived'));
@@ -0,0 +1,5 @@
+ALTER TABLE clearance_requests
+  DROP CONSTRAINT IF EXISTS clearance_requests_status_check;
+
+ALTER TABLE clearance_requests
+  DROP COLUMN IF EXISTS completed_at;
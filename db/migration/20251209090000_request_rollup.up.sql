@@ -0,0 +1,6 @@
+ALTER TABLE clearance_requests
+  ADD COLUMN completed_at timestamptz;
+
+ALTER TABLE clearance_requests
+  ADD CONSTRAINT clearance_requests_status_check
+  CHECK (status IN ('pending', 'in_progress', 'blocked', 'cleared'));
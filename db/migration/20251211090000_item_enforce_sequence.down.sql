@@ -0,0 +1,2 @@
+ALTER TABLE clearance_items
+  DROP COLUMN IF EXISTS enforce_sequence;
@@ -0,0 +1,4 @@
+-- when set, an item can only be approved after every lower-sequence item
+-- of the same request is approved or waived
+ALTER TABLE clearance_items
+  ADD COLUMN enforce_sequence BOOLEAN NOT NULL DEFAULT FALSE;
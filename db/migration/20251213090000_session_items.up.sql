@@ -0,0 +1,14 @@
+-- ============================
+--     SESSION ITEMS
+-- ============================
+-- Per-session selection of clearance items. sequence and approver_staff_id
+-- override the item's defaults when set. Sessions without rows here use
+-- every clearance item.
+CREATE TABLE session_items (
+  session_id BIGINT NOT NULL REFERENCES clearance_sessions(id) ON DELETE CASCADE,
+  clearance_item_id BIGINT NOT NULL REFERENCES clearance_items(id) ON DELETE CASCADE,
+  sequence INT,
+  approver_staff_id BIGINT REFERENCES staff_users(id),
+  created_at timestamptz NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (session_id, clearance_item_id)
+);
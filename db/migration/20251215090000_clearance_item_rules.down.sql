@@ -0,0 +1 @@
+DROP TABLE IF EXISTS clearance_item_rules;
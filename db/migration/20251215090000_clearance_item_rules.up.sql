@@ -0,0 +1,28 @@
+-- ============================
+--     CLEARANCE ITEM RULES
+-- ============================
+-- Applicability rules that restrict which students get an item.
+--   department       : student.department_id = department_id
+--   enrollment_year  : student.enrollment_year within [min, max] (either bound optional)
+--   include_student  : item always applies to student_id
+--   exclude_student  : item never applies to student_id
+CREATE TABLE clearance_item_rules (
+  id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  clearance_item_id BIGINT NOT NULL REFERENCES clearance_items(id) ON DELETE CASCADE,
+  rule_type VARCHAR(30) NOT NULL,
+  department_id BIGINT REFERENCES departments(id) ON DELETE CASCADE,
+  min_enrollment_year INT,
+  max_enrollment_year INT,
+  student_id BIGINT REFERENCES students(id) ON DELETE CASCADE,
+  created_at timestamptz NOT NULL DEFAULT NOW(),
+  CHECK (
+    (rule_type = 'department' AND department_id IS NOT NULL)
+    OR (rule_type = 'enrollment_year'
+        AND (min_enrollment_year IS NOT NULL OR max_enrollment_year IS NOT NULL)
+        AND (min_enrollment_year IS NULL OR max_enrollment_year IS NULL
+             OR min_enrollment_year <= max_enrollment_year))
+    OR (rule_type IN ('include_student', 'exclude_student') AND student_id IS NOT NULL)
+  )
+);
+
+CREATE INDEX ON clearance_item_rules (clearance_item_id);
@@ -0,0 +1,22 @@
+ALTER TABLE clearance_records
+  DROP CONSTRAINT IF EXISTS clearance_records_request_item_key;
+
+ALTER TABLE clearance_records
+  DROP COLUMN IF EXISTS request_id;
+
+ALTER TABLE clearance_records
+  ADD CONSTRAINT clearance_records_student_item_session_key UNIQUE (student_id, clearance_item_id, session_id);
+
+ALTER TABLE clearance_items
+  DROP COLUMN IF EXISTS clearance_type_id;
+
+ALTER TABLE clearance_requests
+  DROP CONSTRAINT IF EXISTS clearance_requests_student_session_type_key;
+
+ALTER TABLE clearance_requests
+  DROP COLUMN IF EXISTS clearance_type_id;
+
+ALTER TABLE clearance_requests
+  ADD CONSTRAINT clearance_requests_student_session_key UNIQUE (student_id, session_id);
+
+DROP TABLE IF EXISTS clearance_types;
@@ -0,0 +1,53 @@
+-- ============================
+--       CLEARANCE TYPES
+-- ============================
+-- A student can hold one request per type and session (graduation,
+-- withdrawal, transfer, ...). Items without a type apply to every type.
+CREATE TABLE clearance_types (
+  id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  code VARCHAR(50) UNIQUE NOT NULL,
+  name VARCHAR(255) NOT NULL,
+  description TEXT NOT NULL DEFAULT '',
+  created_at timestamptz NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO clearance_types (code, name, description)
+VALUES ('general', 'General clearance', 'Default clearance type');
+
+-- requests: existing rows become general clearances
+ALTER TABLE clearance_requests
+  ADD COLUMN clearance_type_id BIGINT REFERENCES clearance_types(id) ON DELETE RESTRICT;
+
+UPDATE clearance_requests
+SET clearance_type_id = (SELECT id FROM clearance_types WHERE code = 'general');
+
+ALTER TABLE clearance_requests
+  ALTER COLUMN clearance_type_id SET NOT NULL;
+
+ALTER TABLE clearance_requests
+  DROP CONSTRAINT clearance_requests_student_session_key;
+
+ALTER TABLE clearance_requests
+  ADD CONSTRAINT clearance_requests_student_session_type_key
+  UNIQUE (student_id, session_id, clearance_type_id);
+
+-- items: NULL means the item applies to every type
+ALTER TABLE clearance_items
+  ADD COLUMN clearance_type_id BIGINT REFERENCES clearance_types(id) ON DELETE SET NULL;
+
+-- records: link each record to the request that created it
+ALTER TABLE clearance_records
+  ADD COLUMN request_id BIGINT REFERENCES clearance_requests(id) ON DELETE CASCADE;
+
+UPDATE clearance_records cr
+SET request_id = rq.id
+FROM clearance_requests rq
+WHERE rq.student_id = cr.student_id AND rq.session_id = cr.session_id;
+
+ALTER TABLE clearance_records
+  DROP CONSTRAINT clearance_records_student_item_session_key;
+
+ALTER TABLE clearance_records
+  ADD CONSTRAINT clearance_records_request_item_key UNIQUE (request_id, clearance_item_id);
+
+CREATE INDEX ON clearance_records (request_id);
@@ -0,0 +1,20 @@
+-- ============================
+--     RECORD ATTACHMENTS
+-- ============================
+-- Files uploaded for a clearance record. The bytes live in the configured
+-- storage backend under storage_key; content_type is sniffed from the
+-- content, never taken from the client.
+CREATE TABLE record_attachments (
+  id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  record_id BIGINT NOT NULL REFERENCES clearance_records(id) ON DELETE CASCADE,
+  storage_key VARCHAR(512) UNIQUE NOT NULL,
+  file_name VARCHAR(255) NOT NULL,
+  content_type VARCHAR(100) NOT NULL,
+  size_bytes BIGINT NOT NULL CHECK (size_bytes > 0),
+  checksum_sha256 CHAR(64) NOT NULL,
+  uploaded_by_role VARCHAR(20) NOT NULL,
+  uploaded_by_id BIGINT NOT NULL,
+  created_at timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX ON record_attachments (record_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS clearance_record_events;
+DROP FUNCTION IF EXISTS clearance_record_events_append_only();
@@ -0,0 +1,27 @@
+-- ============================
+--   CLEARANCE RECORD EVENTS
+-- ============================
+-- Append-only timeline of every status change of a clearance record.
+-- old_status is NULL for the event that created the record.
+CREATE TABLE clearance_record_events (
+  id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  record_id BIGINT NOT NULL REFERENCES clearance_records(id) ON DELETE CASCADE,
+  actor_role VARCHAR(20) NOT NULL,
+  actor_id BIGINT NOT NULL,
+  old_status VARCHAR(50),
+  new_status VARCHAR(50) NOT NULL,
+  note TEXT NOT NULL DEFAULT '',
+  created_at timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX ON clearance_record_events (record_id, created_at);
+
+CREATE FUNCTION clearance_record_events_append_only() RETURNS trigger AS $$
+BEGIN
+  RAISE EXCEPTION 'clearance_record_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER clearance_record_events_no_update
+  BEFORE UPDATE ON clearance_record_events
+  FOR EACH ROW EXECUTE FUNCTION clearance_record_events_append_only();
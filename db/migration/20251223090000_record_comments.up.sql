@@ -0,0 +1,17 @@
+-- ============================
+--       RECORD COMMENTS
+-- ============================
+-- Conversation between a student and the department about a record.
+-- author_role is 'student', 'staff' or 'admin'; author_id points into
+-- the matching table.
+CREATE TABLE record_comments (
+  id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  record_id BIGINT NOT NULL REFERENCES clearance_records(id) ON DELETE CASCADE,
+  author_role VARCHAR(20) NOT NULL CHECK (author_role IN ('student', 'staff', 'admin')),
+  author_id BIGINT NOT NULL,
+  body TEXT NOT NULL CHECK (length(trim(body)) > 0),
+  attachment_id BIGINT REFERENCES record_attachments(id) ON DELETE SET NULL,
+  created_at timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX ON record_comments (record_id, created_at);
@@ -0,0 +1,2 @@
+ALTER TABLE clearance_record_events DROP COLUMN IF EXISTS on_behalf_of_staff_id;
+DROP TABLE IF EXISTS approver_delegations;
@@ -0,0 +1,24 @@
+-- ============================
+--   APPROVER DELEGATIONS
+-- ============================
+-- A staff user hands their approvals to a substitute for an inclusive date
+-- range, e.g. while on leave. The substitute sees the delegator's records in
+-- their queue and may decide on them.
+CREATE TABLE approver_delegations (
+  id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  delegator_staff_id BIGINT NOT NULL REFERENCES staff_users(id) ON DELETE CASCADE,
+  substitute_staff_id BIGINT NOT NULL REFERENCES staff_users(id) ON DELETE CASCADE,
+  starts_on DATE NOT NULL,
+  ends_on DATE NOT NULL,
+  reason TEXT NOT NULL DEFAULT '',
+  created_at timestamptz NOT NULL DEFAULT NOW(),
+  CONSTRAINT approver_delegations_dates_check CHECK (ends_on >= starts_on),
+  CONSTRAINT approver_delegations_not_self CHECK (delegator_staff_id <> substitute_staff_id)
+);
+
+CREATE INDEX ON approver_delegations (substitute_staff_id, starts_on, ends_on);
+CREATE INDEX ON approver_delegations (delegator_staff_id, starts_on, ends_on);
+
+-- Decisions made by a substitute name the approver they acted for.
+ALTER TABLE clearance_record_events
+  ADD COLUMN on_behalf_of_staff_id BIGINT REFERENCES staff_users(id);
@@ -0,0 +1,13 @@
+ALTER TABLE clearance_records
+  DROP COLUMN IF EXISTS assigned_at,
+  DROP COLUMN IF EXISTS assigned_staff_id;
+
+ALTER TABLE clearance_items
+  DROP CONSTRAINT IF EXISTS clearance_items_assignment_strategy_check,
+  DROP CONSTRAINT IF EXISTS clearance_items_approver_pool_check,
+  DROP COLUMN IF EXISTS assignment_strategy,
+  DROP COLUMN IF EXISTS approver_group_id,
+  DROP COLUMN IF EXISTS approver_pool;
+
+DROP TABLE IF EXISTS approver_group_members;
+DROP TABLE IF EXISTS approver_groups;
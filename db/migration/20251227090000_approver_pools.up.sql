@@ -0,0 +1,47 @@
+-- ============================
+--       APPROVER POOLS
+-- ============================
+-- A clearance item can be approved by a pool instead of its single
+-- approver_staff_id:
+--   none        : approver_staff_id decides (the default)
+--   department  : any staff member of the item's department
+--   group       : any member of approver_group_id
+-- assignment_strategy decides who gets a new record of a pooled item:
+--   claim        : nobody; a member claims it from the queue
+--   round_robin  : the member who was assigned this item longest ago
+--   least_loaded : the member with the fewest open assigned records
+-- approver_staff_id stays required and supervises the pool.
+CREATE TABLE approver_groups (
+  id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  name VARCHAR(100) NOT NULL UNIQUE,
+  description TEXT NOT NULL DEFAULT '',
+  created_at timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE approver_group_members (
+  group_id BIGINT NOT NULL REFERENCES approver_groups(id) ON DELETE CASCADE,
+  staff_id BIGINT NOT NULL REFERENCES staff_users(id) ON DELETE CASCADE,
+  is_supervisor BOOLEAN NOT NULL DEFAULT false,
+  created_at timestamptz NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (group_id, staff_id)
+);
+
+CREATE INDEX ON approver_group_members (staff_id);
+
+ALTER TABLE clearance_items
+  ADD COLUMN approver_pool VARCHAR(20) NOT NULL DEFAULT 'none',
+  ADD COLUMN approver_group_id BIGINT REFERENCES approver_groups(id) ON DELETE RESTRICT,
+  ADD COLUMN assignment_strategy VARCHAR(20) NOT NULL DEFAULT 'claim',
+  ADD CONSTRAINT clearance_items_approver_pool_check CHECK (
+    (approver_pool IN ('none', 'department') AND approver_group_id IS NULL)
+    OR (approver_pool = 'group' AND approver_group_id IS NOT NULL)
+  ),
+  ADD CONSTRAINT clearance_items_assignment_strategy_check
+    CHECK (assignment_strategy IN ('claim', 'round_robin', 'least_loaded'));
+
+-- The pool member currently responsible for a record, if any.
+ALTER TABLE clearance_records
+  ADD COLUMN assigned_staff_id BIGINT REFERENCES staff_users(id) ON DELETE SET NULL,
+  ADD COLUMN assigned_at timestamptz;
+
+CREATE INDEX ON clearance_records (assigned_staff_id, status);
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS record_signatures;
+DROP TABLE IF EXISTS clearance_item_signers;
+
+ALTER TABLE clearance_items
+  DROP CONSTRAINT IF EXISTS clearance_items_rejection_policy_check,
+  DROP CONSTRAINT IF EXISTS clearance_items_required_signatures_check,
+  DROP COLUMN IF EXISTS rejection_policy,
+  DROP COLUMN IF EXISTS required_signatures;
@@ -0,0 +1,40 @@
+-- ============================
+--   MULTI-SIGNATURE ITEMS
+-- ============================
+-- Items with required_signatures > 1 are approved once that many distinct
+-- signers approve. Signers are listed in clearance_item_signers, either by
+-- staff user or by role. rejection_policy says what a rejection does:
+--   block : the rejection stands until its signer approves; other
+--           approvals are kept
+--   reset : every signature collected so far is discarded
+ALTER TABLE clearance_items
+  ADD COLUMN required_signatures INT NOT NULL DEFAULT 1,
+  ADD COLUMN rejection_policy VARCHAR(10) NOT NULL DEFAULT 'block',
+  ADD CONSTRAINT clearance_items_required_signatures_check CHECK (required_signatures >= 1),
+  ADD CONSTRAINT clearance_items_rejection_policy_check CHECK (rejection_policy IN ('block', 'reset'));
+
+CREATE TABLE clearance_item_signers (
+  id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  clearance_item_id BIGINT NOT NULL REFERENCES clearance_items(id) ON DELETE CASCADE,
+  staff_id BIGINT REFERENCES staff_users(id) ON DELETE CASCADE,
+  role_id BIGINT REFERENCES roles(id) ON DELETE CASCADE,
+  created_at timestamptz NOT NULL DEFAULT NOW(),
+  CHECK ((staff_id IS NULL) <> (role_id IS NULL))
+);
+
+CREATE INDEX ON clearance_item_signers (clearance_item_id);
+
+-- Every signature ever given. Signatures replaced by a newer decision of
+-- the same signer, or discarded by a reset, get invalidated_at.
+CREATE TABLE record_signatures (
+  id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  record_id BIGINT NOT NULL REFERENCES clearance_records(id) ON DELETE CASCADE,
+  staff_id BIGINT NOT NULL REFERENCES staff_users(id),
+  decision VARCHAR(20) NOT NULL CHECK (decision IN ('approved', 'rejected')),
+  note TEXT NOT NULL DEFAULT '',
+  signed_at timestamptz NOT NULL DEFAULT NOW(),
+  invalidated_at timestamptz
+);
+
+CREATE UNIQUE INDEX record_signatures_active_idx
+  ON record_signatures (record_id, staff_id) WHERE invalidated_at IS NULL;
@@ -0,0 +1,16 @@
+-- ============================
+--   ITEM PREREQUISITES
+-- ============================
+-- A record of item_id becomes actionable once the records of all its
+-- prerequisite items in the same request are approved or waived. Items
+-- with no link between them can be worked on in parallel. The links must
+-- form a graph without cycles; the application checks this on save.
+CREATE TABLE clearance_item_prerequisites (
+  item_id BIGINT NOT NULL REFERENCES clearance_items(id) ON DELETE CASCADE,
+  prerequisite_item_id BIGINT NOT NULL REFERENCES clearance_items(id) ON DELETE CASCADE,
+  created_at timestamptz NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (item_id, prerequisite_item_id),
+  CHECK (item_id <> prerequisite_item_id)
+);
+
+CREATE INDEX ON clearance_item_prerequisites (prerequisite_item_id);
@@ -0,0 +1,31 @@
+-- ============================
+--   STUDENT OBLIGATIONS
+-- ============================
+-- Concrete things a student owes a department: an unreturned book, a lab
+-- equipment fee, a dorm key. amount is in the currency's minor unit (for
+-- example cents) and is 0 for obligations without a fee. While an
+-- obligation is open, records of items belonging to its department can't
+-- be approved.
+CREATE TABLE student_obligations (
+  id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  student_id BIGINT NOT NULL REFERENCES students(id) ON DELETE CASCADE,
+  department_id BIGINT NOT NULL REFERENCES departments(id),
+  obligation_type VARCHAR(20) NOT NULL
+    CHECK (obligation_type IN ('book', 'equipment', 'key', 'fee', 'other')),
+  description TEXT NOT NULL DEFAULT '',
+  amount BIGINT NOT NULL DEFAULT 0 CHECK (amount >= 0),
+  currency CHAR(3) NOT NULL DEFAULT 'USD',
+  status VARCHAR(20) NOT NULL DEFAULT 'open'
+    CHECK (status IN ('open', 'resolved', 'waived')),
+  raised_by_role VARCHAR(20) NOT NULL,
+  raised_by_id BIGINT NOT NULL,
+  resolved_by_role VARCHAR(20),
+  resolved_by_id BIGINT,
+  resolution_note TEXT NOT NULL DEFAULT '',
+  resolved_at timestamptz,
+  created_at timestamptz NOT NULL DEFAULT NOW(),
+  CHECK ((status = 'open') = (resolved_at IS NULL))
+);
+
+CREATE INDEX ON student_obligations (student_id, department_id) WHERE status = 'open';
+CREATE INDEX ON student_obligations (department_id, status);
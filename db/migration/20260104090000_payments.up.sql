@@ -0,0 +1,27 @@
+-- ============================
+--   PAYMENTS
+-- ============================
+-- Online payments of obligation fees. reference is our idempotent
+-- reference: retrying a payment with the same reference returns the
+-- existing payment instead of charging twice, and the provider's callbacks
+-- are matched to payments by it.
+CREATE TABLE payments (
+  id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  obligation_id BIGINT NOT NULL REFERENCES student_obligations(id),
+  student_id BIGINT NOT NULL REFERENCES students(id) ON DELETE CASCADE,
+  reference VARCHAR(100) NOT NULL UNIQUE,
+  provider VARCHAR(30) NOT NULL,
+  provider_payment_id VARCHAR(100) NOT NULL DEFAULT '',
+  checkout_url TEXT NOT NULL DEFAULT '',
+  amount BIGINT NOT NULL CHECK (amount > 0),
+  currency CHAR(3) NOT NULL,
+  status VARCHAR(20) NOT NULL DEFAULT 'pending'
+    CHECK (status IN ('pending', 'succeeded', 'failed')),
+  failure_reason TEXT NOT NULL DEFAULT '',
+  confirmed_at timestamptz,
+  created_at timestamptz NOT NULL DEFAULT NOW(),
+  updated_at timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX ON payments (obligation_id);
+CREATE INDEX ON payments (student_id, created_at);
@@ -0,0 +1,22 @@
+-- ============================
+--   CLEARANCE CERTIFICATES
+-- ============================
+-- One certificate per fully cleared request. payload is the exact JSON
+-- that was signed with the server's Ed25519 key; it is kept as TEXT so the
+-- bytes can be verified again later. The rendered PDF lives in attachment
+-- storage under storage_key.
+CREATE TABLE clearance_certificates (
+  id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  request_id BIGINT NOT NULL UNIQUE REFERENCES clearance_requests(id) ON DELETE CASCADE,
+  student_id BIGINT NOT NULL REFERENCES students(id) ON DELETE CASCADE,
+  certificate_number VARCHAR(50) NOT NULL UNIQUE,
+  storage_key TEXT NOT NULL,
+  size_bytes BIGINT NOT NULL,
+  checksum_sha256 CHAR(64) NOT NULL,
+  payload TEXT NOT NULL,
+  signature TEXT NOT NULL,
+  key_id VARCHAR(32) NOT NULL,
+  issued_at timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX ON clearance_certificates (student_id);
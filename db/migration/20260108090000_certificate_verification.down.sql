@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS clearance_certificates_active_request_key;
+DELETE FROM clearance_certificates WHERE revoked_at IS NOT NULL;
+ALTER TABLE clearance_certificates
+  ADD CONSTRAINT clearance_certificates_request_id_key UNIQUE (request_id);
+
+ALTER TABLE clearance_certificates
+  DROP COLUMN IF EXISTS revocation_reason,
+  DROP COLUMN IF EXISTS revoked_at,
+  DROP COLUMN IF EXISTS verification_code;
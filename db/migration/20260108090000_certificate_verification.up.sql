@@ -0,0 +1,23 @@
+-- ============================
+--   CERTIFICATE VERIFICATION
+-- ============================
+-- verification_code is printed on the certificate and encoded in its QR
+-- code so anyone can check it at GET /verify/:code. Certificates issued
+-- before this migration get a code too, although it is not on their PDF.
+ALTER TABLE clearance_certificates ADD COLUMN verification_code VARCHAR(32);
+UPDATE clearance_certificates
+SET verification_code = upper(substr(md5(random()::text || id::text), 1, 16));
+ALTER TABLE clearance_certificates ALTER COLUMN verification_code SET NOT NULL;
+ALTER TABLE clearance_certificates
+  ADD CONSTRAINT clearance_certificates_verification_code_key UNIQUE (verification_code);
+
+-- A certificate is revoked when its request is reopened. The request can
+-- then be cleared again and get a new certificate, so only one active
+-- certificate per request remains unique.
+ALTER TABLE clearance_certificates
+  ADD COLUMN revoked_at timestamptz,
+  ADD COLUMN revocation_reason TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE clearance_certificates DROP CONSTRAINT clearance_certificates_request_id_key;
+CREATE UNIQUE INDEX clearance_certificates_active_request_key
+  ON clearance_certificates (request_id) WHERE revoked_at IS NULL;
@@ -0,0 +1,9 @@
+ALTER TABLE clearance_record_events
+  DROP CONSTRAINT clearance_record_events_record_id_fkey,
+  ADD CONSTRAINT clearance_record_events_record_id_fkey
+    FOREIGN KEY (record_id) REFERENCES clearance_records(id) ON DELETE CASCADE;
+
+DROP TRIGGER IF EXISTS clearance_record_events_no_update ON clearance_record_events;
+CREATE TRIGGER clearance_record_events_no_update
+  BEFORE UPDATE ON clearance_record_events
+  FOR EACH ROW EXECUTE FUNCTION clearance_record_events_append_only();
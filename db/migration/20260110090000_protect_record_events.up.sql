@@ -0,0 +1,12 @@
+-- The append-only trigger only covered updates, and deleting a record
+-- cascaded to its events, so a record's history could still be erased.
+-- Records that have a history can no longer be deleted.
+DROP TRIGGER IF EXISTS clearance_record_events_no_update ON clearance_record_events;
+CREATE TRIGGER clearance_record_events_no_update
+  BEFORE UPDATE OR DELETE ON clearance_record_events
+  FOR EACH ROW EXECUTE FUNCTION clearance_record_events_append_only();
+
+ALTER TABLE clearance_record_events
+  DROP CONSTRAINT clearance_record_events_record_id_fkey,
+  ADD CONSTRAINT clearance_record_events_record_id_fkey
+    FOREIGN KEY (record_id) REFERENCES clearance_records(id) ON DELETE RESTRICT;
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS payments_active_obligation_key;
@@ -0,0 +1,17 @@
+-- An obligation may only have one payment in flight or paid at a time, so
+-- a second checkout cannot charge the same fee twice. Older duplicate
+-- pending payments are closed first.
+UPDATE payments p
+SET status = 'failed',
+    failure_reason = 'superseded by a newer payment',
+    updated_at = NOW()
+WHERE p.status = 'pending'
+  AND EXISTS (
+    SELECT 1 FROM payments newer
+    WHERE newer.obligation_id = p.obligation_id
+      AND newer.status IN ('pending', 'succeeded')
+      AND (newer.status = 'succeeded' OR newer.id > p.id)
+  );
+
+CREATE UNIQUE INDEX payments_active_obligation_key
+  ON payments (obligation_id) WHERE status IN ('pending', 'succeeded');
@@ -0,0 +1 @@
+ALTER TABLE record_attachments DROP COLUMN IF EXISTS source;
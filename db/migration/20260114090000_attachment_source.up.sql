@@ -0,0 +1,9 @@
+-- Files sent with a comment are part of the conversation, not the
+-- record's evidence, so they must not satisfy requires_attachment.
+ALTER TABLE record_attachments
+  ADD COLUMN source VARCHAR(20) NOT NULL DEFAULT 'record'
+  CHECK (source IN ('record', 'comment'));
+
+UPDATE record_attachments ra
+SET source = 'comment'
+WHERE EXISTS (SELECT 1 FROM record_comments c WHERE c.attachment_id = ra.id);
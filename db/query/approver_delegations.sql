@@ -0,0 +1,30 @@
+-- name: CreateApproverDelegation :one
+INSERT INTO approver_delegations (
+    delegator_staff_id, substitute_staff_id, starts_on, ends_on, reason
+) VALUES ($1,$2,$3,$4,$5)
+RETURNING *;
+
+-- name: GetApproverDelegation :one
+SELECT * FROM approver_delegations WHERE id = $1 LIMIT 1;
+
+-- name: ListApproverDelegationsForStaff :many
+SELECT * FROM approver_delegations
+WHERE delegator_staff_id = $1 OR substitute_staff_id = $1
+ORDER BY starts_on DESC, id DESC;
+
+-- name: CountOverlappingDelegations :one
+SELECT COUNT(*) FROM approver_delegations
+WHERE delegator_staff_id = $1
+  AND ends_on >= sqlc.arg('starts_on')::date
+  AND starts_on <= sqlc.arg('ends_on')::date;
+
+-- name: HasActiveDelegation :one
+SELECT EXISTS (
+    SELECT 1 FROM approver_delegations
+    WHERE delegator_staff_id = $1
+      AND substitute_staff_id = $2
+      AND CURRENT_DATE BETWEEN starts_on AND ends_on
+);
+
+-- name: DeleteApproverDelegation :exec
+DELETE FROM approver_delegations WHERE id = $1;
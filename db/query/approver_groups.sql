@@ -0,0 +1,68 @@
+-- name: CreateApproverGroup :one
+INSERT INTO approver_groups (name, description)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: GetApproverGroup :one
+SELECT * FROM approver_groups WHERE id = $1 LIMIT 1;
+
+-- name: ListApproverGroups :many
+SELECT * FROM approver_groups ORDER BY name;
+
+-- name: DeleteApproverGroup :exec
+DELETE FROM approver_groups WHERE id = $1;
+
+-- name: CountItemsUsingApproverGroup :one
+SELECT COUNT(*) FROM clearance_items WHERE approver_group_id = $1;
+
+-- name: UpsertApproverGroupMember :one
+INSERT INTO approver_group_members (group_id, staff_id, is_supervisor)
+VALUES ($1, $2, $3)
+ON CONFLICT (group_id, staff_id) DO UPDATE SET is_supervisor = EXCLUDED.is_supervisor
+RETURNING *;
+
+-- name: ListApproverGroupMembers :many
+SELECT * FROM approver_group_members
+WHERE group_id = $1
+ORDER BY staff_id;
+
+-- name: DeleteApproverGroupMember :execrows
+DELETE FROM approver_group_members
+WHERE group_id = $1 AND staff_id = $2;
+
+-- name: IsApproverGroupSupervisor :one
+SELECT EXISTS (
+    SELECT 1 FROM approver_group_members
+    WHERE group_id = $1 AND staff_id = $2 AND is_supervisor
+);
+
+-- name: ListItemPoolMembers :many
+-- Pool members of an item with their open workload and when they were
+-- last assigned a record of the item.
+SELECT
+    su.id AS staff_id,
+    (SELECT COUNT(*) FROM clearance_records r
+     WHERE r.assigned_staff_id = su.id
+       AND r.status IN ('pending', 'in_review', 'resubmitted')) AS open_records,
+    (SELECT MAX(r.assigned_at) FROM clearance_records r
+     WHERE r.assigned_staff_id = su.id
+       AND r.clearance_item_id = ci.id)::timestamptz AS last_assigned_at
+FROM clearance_items ci
+JOIN staff_users su
+  ON (ci.approver_pool = 'department' AND su.department_id = ci.department_id)
+  OR (ci.approver_pool = 'group' AND su.id IN (
+        SELECT m.staff_id FROM approver_group_members m
+        WHERE m.group_id = ci.approver_group_id))
+WHERE ci.id = $1
+ORDER BY su.id;
+
+-- name: IsItemPoolMember :one
+SELECT EXISTS (
+    SELECT 1 FROM clearance_items ci
+    JOIN staff_users su ON su.id = sqlc.arg('staff_id')
+    WHERE ci.id = sqlc.arg('item_id')
+      AND ((ci.approver_pool = 'department' AND su.department_id = ci.department_id)
+        OR (ci.approver_pool = 'group' AND EXISTS (
+              SELECT 1 FROM approver_group_members m
+              WHERE m.group_id = ci.approver_group_id AND m.staff_id = su.id)))
+);
@@ -0,0 +1,42 @@
+-- name: CreateClearanceCertificate :one
+INSERT INTO clearance_certificates (
+    request_id, student_id, certificate_number, storage_key, size_bytes,
+    checksum_sha256, payload, signature, key_id, issued_at, verification_code
+) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11)
+RETURNING *;
+
+-- name: GetCertificateByRequest :one
+-- Returns the request's active certificate; revoked ones are kept for
+-- verification only.
+SELECT * FROM clearance_certificates
+WHERE request_id = $1 AND revoked_at IS NULL;
+
+-- name: GetCertificateByVerificationCode :one
+SELECT * FROM clearance_certificates WHERE verification_code = $1;
+
+-- name: CountRequestCertificates :one
+SELECT COUNT(*) FROM clearance_certificates WHERE request_id = $1;
+
+-- name: RevokeRequestCertificates :many
+UPDATE clearance_certificates
+SET revoked_at = NOW(),
+    revocation_reason = $2
+WHERE request_id = $1 AND revoked_at IS NULL
+RETURNING *;
+
+-- name: ListCertificateItems :many
+SELECT
+    cr.id AS record_id,
+    ci.title,
+    d.name AS department_name,
+    cr.status,
+    COALESCE(su.full_name, '')::text AS approver_name,
+    cr.handled_at
+FROM clearance_records cr
+JOIN clearance_items ci ON ci.id = cr.clearance_item_id
+JOIN departments d ON d.id = ci.department_id
+LEFT JOIN staff_users su ON su.id = cr.handled_by
+LEFT JOIN session_items si
+  ON si.session_id = cr.session_id AND si.clearance_item_id = cr.clearance_item_id
+WHERE cr.request_id = $1
+ORDER BY COALESCE(si.sequence, ci.sequence), cr.id;
@@ -0,0 +1,20 @@
+-- name: CreateItemPrerequisite :one
+INSERT INTO clearance_item_prerequisites (
+    item_id, prerequisite_item_id
+) VALUES ($1,$2)
+RETURNING *;
+
+-- name: ListItemPrerequisites :many
+SELECT * FROM clearance_item_prerequisites
+WHERE item_id = $1
+ORDER BY prerequisite_item_id;
+
+-- name: ListAllItemPrerequisites :many
+SELECT * FROM clearance_item_prerequisites
+ORDER BY item_id, prerequisite_item_id;
+
+-- name: DeleteItemPrerequisites :exec
+DELETE FROM clearance_item_prerequisites WHERE item_id = $1;
+
+-- name: LockItemPrerequisites :exec
+LOCK TABLE clearance_item_prerequisites IN SHARE ROW EXCLUSIVE MODE;
@@ -0,0 +1,20 @@
+-- name: CreateClearanceItemRule :one
+INSERT INTO clearance_item_rules (
+    clearance_item_id, rule_type, department_id,
+    min_enrollment_year, max_enrollment_year, student_id, created_at
+) VALUES ($1,$2,$3,$4,$5,$6,NOW())
+RETURNING *;
+
+-- name: ListRulesByItem :many
+SELECT * FROM clearance_item_rules
+WHERE clearance_item_id = $1
+ORDER BY id;
+
+-- name: ListRulesForItems :many
+SELECT * FROM clearance_item_rules
+WHERE clearance_item_id = ANY(sqlc.arg(item_ids)::bigint[])
+ORDER BY clearance_item_id, id;
+
+-- name: DeleteClearanceItemRule :exec
+DELETE FROM clearance_item_rules
+WHERE id = $1 AND clearance_item_id = $2;
@@ -0,0 +1,21 @@
+-- name: CreateClearanceItemSigner :one
+INSERT INTO clearance_item_signers (
+    clearance_item_id, staff_id, role_id
+) VALUES ($1,$2,$3)
+RETURNING *;
+
+-- name: ListClearanceItemSigners :many
+SELECT * FROM clearance_item_signers
+WHERE clearance_item_id = $1
+ORDER BY id;
+
+-- name: DeleteClearanceItemSigners :exec
+DELETE FROM clearance_item_signers WHERE clearance_item_id = $1;
+
+-- name: IsItemSigner :one
+SELECT EXISTS (
+    SELECT 1 FROM clearance_item_signers sg
+    JOIN staff_users su ON su.id = sqlc.arg('staff_id')
+    WHERE sg.clearance_item_id = sqlc.arg('item_id')
+      AND (sg.staff_id = su.id OR sg.role_id = su.role_id)
+);
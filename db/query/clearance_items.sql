@@ -2,8 +2,8 @@
 INSERT INTO clearance_items (
     code, title, description, department_id,
     approver_staff_id, requires_attachment,
-    sequence, created_at
-) VALUES ($1,$2,$3,$4,$5,$6,$7,NOW())
+    sequence, enforce_sequence, clearance_type_id, created_at
+) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,NOW())
 RETURNING *;
 
 -- name: GetClearanceItem :one
@@ -23,8 +23,23 @@ UPDATE clearance_items SET
     department_id = $4,
     approver_staff_id = $5,
     requires_attachment = $6,
-    sequence = $7
-WHERE id = $8 RETURNING *;
+    sequence = $7,
+    enforce_sequence = $8,
+    clearance_type_id = $9
+WHERE id = $10 RETURNING *;
 
 -- name: DeleteClearanceItem :exec
 DELETE FROM clearance_items WHERE id = $1;
+
+-- name: SetClearanceItemPool :one
+UPDATE clearance_items SET
+    approver_pool = $2,
+    approver_group_id = $3,
+    assignment_strategy = $4
+WHERE id = $1 RETURNING *;
+
+-- name: SetClearanceItemSignaturePolicy :one
+UPDATE clearance_items SET
+    required_signatures = $2,
+    rejection_policy = $3
+WHERE id = $1 RETURNING *;
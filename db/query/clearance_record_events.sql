@@ -0,0 +1,14 @@
+-- name: CreateClearanceRecordEvent :one
+INSERT INTO clearance_record_events (
+    record_id, actor_role, actor_id, old_status, new_status, note, on_behalf_of_staff_id
+) VALUES ($1,$2,$3,$4,$5,$6,$7)
+RETURNING *;
+
+-- name: ListClearanceRecordEvents :many
+SELECT * FROM clearance_record_events
+WHERE record_id = $1
+ORDER BY created_at, id;
+
+-- name: CountClearanceRecordEventsByStatus :one
+SELECT COUNT(*) FROM clearance_record_events
+WHERE record_id = $1 AND new_status = $2;
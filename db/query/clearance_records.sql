@@ -1,8 +1,8 @@
 -- name: CreateClearanceRecord :one
 INSERT INTO clearance_records (
     student_id, clearance_item_id, session_id,
-    status, note, handled_by, attachment_url, updated_at
-) VALUES ($1,$2,$3,$4,$5,$6,$7,NOW())
+    status, note, handled_by, attachment_url, request_id, updated_at
+) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,NOW())
 RETURNING *;
 
 -- name: GetClearanceRecord :one
@@ -29,5 +29,188 @@ UPDATE clearance_records SET
 WHERE id = $6
 RETURNING *;
 
--- name: DeleteClearanceRecord :exec
-DELETE FROM clearance_records WHERE id = $1;
+-- name: ListRecordsByStudentForStaff :many
+SELECT cr.* FROM clearance_records cr
+JOIN clearance_items ci ON ci.id = cr.clearance_item_id
+LEFT JOIN session_items si
+  ON si.session_id = cr.session_id AND si.clearance_item_id = cr.clearance_item_id
+WHERE cr.student_id = $1
+  AND (COALESCE(si.approver_staff_id, ci.approver_staff_id) = $2 OR cr.assigned_staff_id = $2
+       OR ci.department_id = $3)
+ORDER BY cr.clearance_item_id;
+
+-- name: ListRecordsBySessionForStaff :many
+SELECT cr.* FROM clearance_records cr
+JOIN clearance_items ci ON ci.id = cr.clearance_item_id
+LEFT JOIN session_items si
+  ON si.session_id = cr.session_id AND si.clearance_item_id = cr.clearance_item_id
+WHERE cr.session_id = $1
+  AND (COALESCE(si.approver_staff_id, ci.approver_staff_id) = $2 OR cr.assigned_staff_id = $2
+       OR ci.department_id = $3)
+ORDER BY cr.student_id;
+
+-- name: GetClearanceRecordForUpdate :one
+SELECT * FROM clearance_records
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: GetRecordStatusCounts :one
+SELECT
+    COUNT(*) AS total,
+    COUNT(*) FILTER (WHERE status IN ('approved', 'waived')) AS done,
+    COUNT(*) FILTER (WHERE status = 'rejected') AS rejected
+FROM clearance_records
+WHERE request_id = $1;
+
+-- name: ListRequestRecordItems :many
+SELECT
+    cr.id AS record_id,
+    cr.status,
+    ci.id AS item_id,
+    ci.title,
+    COALESCE(si.sequence, ci.sequence) AS sequence,
+    ci.enforce_sequence,
+    COALESCE(si.approver_staff_id, ci.approver_staff_id) AS approver_staff_id,
+    cr.assigned_staff_id,
+    ARRAY(
+        SELECT p.prerequisite_item_id FROM clearance_item_prerequisites p
+        WHERE p.item_id = ci.id
+        ORDER BY p.prerequisite_item_id
+    )::bigint[] AS prerequisite_item_ids
+FROM clearance_records cr
+JOIN clearance_items ci ON ci.id = cr.clearance_item_id
+LEFT JOIN session_items si
+  ON si.session_id = cr.session_id AND si.clearance_item_id = cr.clearance_item_id
+WHERE cr.request_id = $1
+ORDER BY COALESCE(si.sequence, ci.sequence), cr.id;
+
+-- name: GetRecordItem :one
+SELECT
+    cr.id AS record_id,
+    cr.student_id,
+    cr.session_id,
+    ci.id AS item_id,
+    ci.title,
+    ci.department_id,
+    COALESCE(si.approver_staff_id, ci.approver_staff_id) AS approver_staff_id,
+    ci.requires_attachment,
+    COALESCE(si.sequence, ci.sequence) AS sequence,
+    ci.approver_pool,
+    ci.approver_group_id,
+    cr.assigned_staff_id,
+    ci.required_signatures,
+    ci.rejection_policy
+FROM clearance_records cr
+JOIN clearance_items ci ON ci.id = cr.clearance_item_id
+LEFT JOIN session_items si
+  ON si.session_id = cr.session_id AND si.clearance_item_id = cr.clearance_item_id
+WHERE cr.id = $1;
+
+-- name: ListRecordsMissingAttachments :many
+SELECT
+    cr.id AS record_id,
+    cr.request_id,
+    cr.session_id,
+    cr.status,
+    cr.updated_at,
+    s.id AS student_id,
+    s.student_number,
+    s.first_name,
+    s.last_name,
+    ci.id AS item_id,
+    ci.title,
+    COALESCE(si.approver_staff_id, ci.approver_staff_id) AS approver_staff_id
+FROM clearance_records cr
+JOIN clearance_items ci ON ci.id = cr.clearance_item_id
+JOIN students s ON s.id = cr.student_id
+LEFT JOIN session_items si
+  ON si.session_id = cr.session_id AND si.clearance_item_id = cr.clearance_item_id
+WHERE ci.requires_attachment
+  AND cr.status NOT IN ('approved', 'waived')
+  AND NOT EXISTS (SELECT 1 FROM record_attachments ra WHERE ra.record_id = cr.id AND ra.source = 'record')
+  AND (sqlc.narg('approver_staff_id')::bigint IS NULL
+       OR COALESCE(si.approver_staff_id, ci.approver_staff_id) = sqlc.narg('approver_staff_id')::bigint)
+  AND (sqlc.narg('session_id')::bigint IS NULL OR cr.session_id = sqlc.narg('session_id')::bigint)
+ORDER BY cr.updated_at, cr.id;
+
+-- name: ListApproverQueue :many
+SELECT
+    cr.id AS record_id,
+    cr.request_id,
+    cr.session_id,
+    cs.name AS session_name,
+    cr.status,
+    cr.updated_at AS waiting_since,
+    s.id AS student_id,
+    s.student_number,
+    s.first_name,
+    s.last_name,
+    s.department_id,
+    ci.id AS item_id,
+    ci.title,
+    resp.staff_id AS responsible_staff_id
+FROM clearance_records cr
+JOIN clearance_items ci ON ci.id = cr.clearance_item_id
+JOIN students s ON s.id = cr.student_id
+JOIN clearance_sessions cs ON cs.id = cr.session_id
+LEFT JOIN session_items si
+  ON si.session_id = cr.session_id AND si.clearance_item_id = cr.clearance_item_id
+CROSS JOIN LATERAL (
+    SELECT CASE WHEN ci.approver_pool = 'none'
+                THEN COALESCE(si.approver_staff_id, ci.approver_staff_id)
+                ELSE cr.assigned_staff_id END AS staff_id
+) resp
+WHERE (resp.staff_id = sqlc.arg('approver_staff_id')
+       OR EXISTS (
+           SELECT 1 FROM approver_delegations d
+           WHERE d.delegator_staff_id = resp.staff_id
+             AND d.substitute_staff_id = sqlc.arg('approver_staff_id')
+             AND CURRENT_DATE BETWEEN d.starts_on AND d.ends_on)
+       OR (resp.staff_id IS NULL AND (
+           (ci.approver_pool = 'department' AND ci.department_id = (
+               SELECT su.department_id FROM staff_users su
+               WHERE su.id = sqlc.arg('approver_staff_id')))
+           OR (ci.approver_pool = 'group' AND EXISTS (
+               SELECT 1 FROM approver_group_members m
+               WHERE m.group_id = ci.approver_group_id
+                 AND m.staff_id = sqlc.arg('approver_staff_id')))))
+       OR (ci.required_signatures > 1
+           AND EXISTS (
+               SELECT 1 FROM clearance_item_signers sg
+               JOIN staff_users su ON su.id = sqlc.arg('approver_staff_id')
+               WHERE sg.clearance_item_id = ci.id
+                 AND (sg.staff_id = su.id OR sg.role_id = su.role_id))
+           AND NOT EXISTS (
+               SELECT 1 FROM record_signatures rs
+               WHERE rs.record_id = cr.id
+                 AND rs.staff_id = sqlc.arg('approver_staff_id')
+                 AND rs.decision = 'approved'
+                 AND rs.invalidated_at IS NULL)))
+  AND cr.status = ANY(sqlc.arg('statuses')::text[])
+  AND (sqlc.narg('session_id')::bigint IS NULL OR cr.session_id = sqlc.narg('session_id')::bigint)
+  AND (sqlc.narg('department_id')::bigint IS NULL OR s.department_id = sqlc.narg('department_id')::bigint)
+  AND (sqlc.narg('waiting_before')::timestamptz IS NULL OR cr.updated_at <= sqlc.narg('waiting_before')::timestamptz)
+  AND (sqlc.narg('cursor_updated_at')::timestamptz IS NULL
+       OR (NOT sqlc.arg('newest_first')::bool
+           AND (cr.updated_at, cr.id) > (sqlc.narg('cursor_updated_at')::timestamptz, sqlc.narg('cursor_id')::bigint))
+       OR (sqlc.arg('newest_first')::bool
+           AND (cr.updated_at, cr.id) < (sqlc.narg('cursor_updated_at')::timestamptz, sqlc.narg('cursor_id')::bigint)))
+ORDER BY
+    CASE WHEN sqlc.arg('newest_first')::bool THEN cr.updated_at END DESC,
+    CASE WHEN sqlc.arg('newest_first')::bool THEN cr.id END DESC,
+    cr.updated_at,
+    cr.id
+LIMIT sqlc.arg('page_limit');
+
+-- name: AssignClearanceRecord :one
+UPDATE clearance_records
+SET assigned_staff_id = $2, assigned_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: ClaimClearanceRecord :one
+-- Fails with no rows when someone else claimed the record first.
+UPDATE clearance_records
+SET assigned_staff_id = $2, assigned_at = NOW()
+WHERE id = $1 AND assigned_staff_id IS NULL
+RETURNING *;
@@ -1,7 +1,7 @@
 -- name: CreateClearanceRequest :one
 INSERT INTO clearance_requests (
-    student_id, session_id
-) VALUES ($1, $2)
+    student_id, session_id, clearance_type_id
+) VALUES ($1, $2, $3)
 RETURNING *;
 
 -- name: GetClearanceRequest :one
@@ -25,3 +25,19 @@ WHERE id = $2;
 -- name: ListAllRequests :many
 SELECT * FROM clearance_requests
 ORDER BY created_at DESC;
+
+-- name: GetClearanceRequestForUpdate :one
+SELECT * FROM clearance_requests
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: UpdateClearanceRequestProgress :one
+UPDATE clearance_requests
+SET status = $2,
+    completed_at = $3
+WHERE id = $1
+RETURNING *;
+
+-- name: CountRequestsByType :one
+SELECT COUNT(*) FROM clearance_requests
+WHERE clearance_type_id = $1;
@@ -0,0 +1,25 @@
+-- name: CreateClearanceType :one
+INSERT INTO clearance_types (
+    code, name, description, created_at
+) VALUES ($1,$2,$3,NOW())
+RETURNING *;
+
+-- name: GetClearanceType :one
+SELECT * FROM clearance_types WHERE id = $1 LIMIT 1;
+
+-- name: GetClearanceTypeByCode :one
+SELECT * FROM clearance_types WHERE code = $1 LIMIT 1;
+
+-- name: ListClearanceTypes :many
+SELECT * FROM clearance_types ORDER BY id;
+
+-- name: UpdateClearanceType :one
+UPDATE clearance_types SET
+    code = $1,
+    name = $2,
+    description = $3
+WHERE id = $4
+RETURNING *;
+
+-- name: DeleteClearanceType :exec
+DELETE FROM clearance_types WHERE id = $1;
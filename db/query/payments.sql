@@ -0,0 +1,46 @@
+-- name: CreatePayment :one
+INSERT INTO payments (
+    obligation_id, student_id, reference, provider, amount, currency
+) VALUES ($1,$2,$3,$4,$5,$6)
+RETURNING *;
+
+-- name: GetPaymentByReference :one
+SELECT * FROM payments WHERE reference = $1;
+
+-- name: GetPaymentByReferenceForUpdate :one
+SELECT * FROM payments WHERE reference = $1 FOR UPDATE;
+
+-- name: GetActiveObligationPayment :one
+SELECT * FROM payments
+WHERE obligation_id = $1 AND status IN ('pending', 'succeeded');
+
+-- name: ListStudentPayments :many
+SELECT * FROM payments
+WHERE student_id = $1
+ORDER BY created_at DESC, id DESC;
+
+-- name: SetPaymentCheckout :one
+UPDATE payments SET
+    provider_payment_id = $2,
+    checkout_url = $3,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: CompletePayment :one
+UPDATE payments SET
+    status = sqlc.arg('status')::text,
+    provider_payment_id = COALESCE(NULLIF(sqlc.arg('provider_payment_id')::text, ''), provider_payment_id),
+    failure_reason = sqlc.arg('failure_reason')::text,
+    confirmed_at = CASE WHEN sqlc.arg('status')::text = 'succeeded' THEN NOW() END,
+    updated_at = NOW()
+WHERE id = sqlc.arg('id') AND status = 'pending'
+RETURNING *;
+
+-- name: ListStudentRecordsWaitingInDepartment :many
+SELECT cr.* FROM clearance_records cr
+JOIN clearance_items ci ON ci.id = cr.clearance_item_id
+WHERE cr.student_id = $1
+  AND ci.department_id = $2
+  AND cr.status = 'pending'
+ORDER BY cr.id;
@@ -0,0 +1,21 @@
+-- name: CreateRecordAttachment :one
+INSERT INTO record_attachments (
+    record_id, storage_key, file_name, content_type,
+    size_bytes, checksum_sha256, uploaded_by_role, uploaded_by_id, source
+) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9)
+RETURNING *;
+
+-- name: GetRecordAttachment :one
+SELECT * FROM record_attachments WHERE id = $1 LIMIT 1;
+
+-- name: ListRecordAttachments :many
+SELECT * FROM record_attachments
+WHERE record_id = $1
+ORDER BY created_at, id;
+
+-- name: CountRecordAttachments :one
+SELECT COUNT(*) FROM record_attachments
+WHERE record_id = $1 AND source = 'record';
+
+-- name: DeleteRecordAttachment :exec
+DELETE FROM record_attachments WHERE id = $1;
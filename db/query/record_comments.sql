@@ -0,0 +1,28 @@
+-- name: CreateRecordComment :one
+INSERT INTO record_comments (
+    record_id, author_role, author_id, body, attachment_id
+) VALUES ($1,$2,$3,$4,$5)
+RETURNING *;
+
+-- name: ListRecordComments :many
+SELECT
+    rc.id,
+    rc.record_id,
+    rc.author_role,
+    rc.author_id,
+    COALESCE(
+        CASE rc.author_role
+            WHEN 'student' THEN s.first_name || ' ' || s.last_name
+            WHEN 'staff' THEN su.full_name
+            WHEN 'admin' THEN a.full_name
+        END, ''
+    )::text AS author_name,
+    rc.body,
+    rc.attachment_id,
+    rc.created_at
+FROM record_comments rc
+LEFT JOIN students s ON rc.author_role = 'student' AND s.id = rc.author_id
+LEFT JOIN staff_users su ON rc.author_role = 'staff' AND su.id = rc.author_id
+LEFT JOIN admins a ON rc.author_role = 'admin' AND a.id = rc.author_id
+WHERE rc.record_id = $1
+ORDER BY rc.created_at, rc.id;
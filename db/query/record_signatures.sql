@@ -0,0 +1,24 @@
+-- name: CreateRecordSignature :one
+INSERT INTO record_signatures (
+    record_id, staff_id, decision, note
+) VALUES ($1,$2,$3,$4)
+RETURNING *;
+
+-- name: ListRecordSignatures :many
+SELECT * FROM record_signatures
+WHERE record_id = $1
+ORDER BY signed_at, id;
+
+-- name: ListActiveRecordSignatures :many
+SELECT * FROM record_signatures
+WHERE record_id = $1 AND invalidated_at IS NULL
+ORDER BY signed_at, id;
+
+-- name: InvalidateStaffSignature :exec
+UPDATE record_signatures SET invalidated_at = NOW()
+WHERE record_id = $1 AND staff_id = $2 AND invalidated_at IS NULL;
+
+-- name: InvalidateRecordSignatures :many
+UPDATE record_signatures SET invalidated_at = NOW()
+WHERE record_id = $1 AND invalidated_at IS NULL
+RETURNING *;
@@ -0,0 +1,48 @@
+-- name: UpsertSessionItem :one
+INSERT INTO session_items (
+    session_id, clearance_item_id, sequence, approver_staff_id, created_at
+) VALUES ($1, $2, $3, $4, NOW())
+ON CONFLICT (session_id, clearance_item_id) DO UPDATE SET
+    sequence = EXCLUDED.sequence,
+    approver_staff_id = EXCLUDED.approver_staff_id
+RETURNING *;
+
+-- name: DeleteSessionItem :exec
+DELETE FROM session_items
+WHERE session_id = $1 AND clearance_item_id = $2;
+
+-- name: CountSessionItems :one
+SELECT COUNT(*) FROM session_items
+WHERE session_id = $1;
+
+-- name: CopySessionItems :execrows
+INSERT INTO session_items (
+    session_id, clearance_item_id, sequence, approver_staff_id, created_at
+)
+SELECT sqlc.arg(session_id)::bigint, src.clearance_item_id, src.sequence, src.approver_staff_id, NOW()
+FROM session_items src
+WHERE src.session_id = sqlc.arg(from_session_id)::bigint
+ON CONFLICT (session_id, clearance_item_id) DO NOTHING;
+
+-- name: ListSessionClearanceItems :many
+SELECT
+    ci.id,
+    ci.code,
+    ci.title,
+    ci.description,
+    ci.department_id,
+    COALESCE(si.approver_staff_id, ci.approver_staff_id) AS approver_staff_id,
+    ci.requires_attachment,
+    COALESCE(si.sequence, ci.sequence) AS sequence,
+    ci.created_at,
+    ci.enforce_sequence,
+    ci.clearance_type_id,
+    ci.approver_pool,
+    ci.approver_group_id,
+    ci.assignment_strategy,
+    ci.required_signatures,
+    ci.rejection_policy
+FROM session_items si
+JOIN clearance_items ci ON ci.id = si.clearance_item_id
+WHERE si.session_id = $1
+ORDER BY sequence, ci.id;
@@ -0,0 +1,12 @@
+-- name: UpsertStudentCredential :one
+INSERT INTO student_credentials (
+    student_id, hashed_password, updated_at
+) VALUES ($1, $2, NOW())
+ON CONFLICT (student_id) DO UPDATE SET
+    hashed_password = EXCLUDED.hashed_password,
+    updated_at = NOW()
+RETURNING *;
+
+-- name: GetStudentCredential :one
+SELECT * FROM student_credentials
+WHERE student_id = $1 LIMIT 1;
@@ -0,0 +1,53 @@
+-- name: CreateStudentObligation :one
+INSERT INTO student_obligations (
+    student_id, department_id, obligation_type, description, amount, currency,
+    raised_by_role, raised_by_id
+) VALUES ($1,$2,$3,$4,$5,$6,$7,$8)
+RETURNING *;
+
+-- name: GetStudentObligation :one
+SELECT * FROM student_obligations WHERE id = $1;
+
+-- name: GetStudentObligationForUpdate :one
+SELECT * FROM student_obligations WHERE id = $1 FOR UPDATE;
+
+-- name: ListStudentObligations :many
+SELECT
+    so.id,
+    so.student_id,
+    so.department_id,
+    d.name AS department_name,
+    so.obligation_type,
+    so.description,
+    so.amount,
+    so.currency,
+    so.status,
+    so.resolution_note,
+    so.resolved_at,
+    so.created_at
+FROM student_obligations so
+JOIN departments d ON d.id = so.department_id
+WHERE so.student_id = $1
+ORDER BY so.status = 'open' DESC, so.created_at DESC, so.id DESC;
+
+-- name: ListObligations :many
+SELECT * FROM student_obligations
+WHERE (sqlc.narg('department_id')::bigint IS NULL OR department_id = sqlc.narg('department_id')::bigint)
+  AND (sqlc.narg('student_id')::bigint IS NULL OR student_id = sqlc.narg('student_id')::bigint)
+  AND (sqlc.narg('status')::text IS NULL OR status = sqlc.narg('status')::text)
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg('page_limit') OFFSET sqlc.arg('page_offset');
+
+-- name: CountOpenDepartmentObligations :one
+SELECT COUNT(*) FROM student_obligations
+WHERE student_id = $1 AND department_id = $2 AND status = 'open';
+
+-- name: ResolveStudentObligation :one
+UPDATE student_obligations SET
+    status = $2,
+    resolved_by_role = $3,
+    resolved_by_id = $4,
+    resolution_note = $5,
+    resolved_at = NOW()
+WHERE id = $1 AND status = 'open'
+RETURNING *;
@@ -0,0 +1,105 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Allowed values of clearance_items.approver_pool.
+const (
+	ApproverPoolNone       = "none"
+	ApproverPoolDepartment = "department"
+	ApproverPoolGroup      = "group"
+)
+
+// Allowed values of clearance_items.assignment_strategy.
+const (
+	AssignmentClaim       = "claim"
+	AssignmentRoundRobin  = "round_robin"
+	AssignmentLeastLoaded = "least_loaded"
+)
+
+// IsValidApproverPool reports whether pool is one of the known pool kinds.
+func IsValidApproverPool(pool string) bool {
+	return pool == ApproverPoolNone || pool == ApproverPoolDepartment || pool == ApproverPoolGroup
+}
+
+// IsValidAssignmentStrategy reports whether strategy is one of the known
+// assignment strategies.
+func IsValidAssignmentStrategy(strategy string) bool {
+	return strategy == AssignmentClaim || strategy == AssignmentRoundRobin || strategy == AssignmentLeastLoaded
+}
+
+// PickAssignee chooses the pool member a new record is assigned to. It
+// reports false for the claim strategy and for empty pools, which leave
+// the record for a member to claim. Ties go to the lowest staff ID.
+func PickAssignee(strategy string, members []ListItemPoolMembersRow) (int64, bool) {
+	if len(members) == 0 {
+		return 0, false
+	}
+
+	// never assigned beats assigned long ago beats assigned recently
+	assignedEarlier := func(a, b ListItemPoolMembersRow) bool {
+		if !a.LastAssignedAt.Valid || !b.LastAssignedAt.Valid {
+			return !a.LastAssignedAt.Valid && b.LastAssignedAt.Valid
+		}
+		return a.LastAssignedAt.Time.Before(b.LastAssignedAt.Time)
+	}
+
+	var better func(a, b ListItemPoolMembersRow) bool
+	switch strategy {
+	case AssignmentRoundRobin:
+		better = assignedEarlier
+	case AssignmentLeastLoaded:
+		better = func(a, b ListItemPoolMembersRow) bool {
+			if a.OpenRecords != b.OpenRecords {
+				return a.OpenRecords < b.OpenRecords
+			}
+			return assignedEarlier(a, b)
+		}
+	default:
+		return 0, false
+	}
+
+	best := members[0]
+	for _, m := range members[1:] {
+		if better(m, best) || (!better(best, m) && m.StaffID < best.StaffID) {
+			best = m
+		}
+	}
+	return best.StaffID, true
+}
+
+// AutoAssignRecord assigns a new record of a pooled item according to the
+// item's assignment strategy. Records of items without a pool, and records
+// left for claiming, are returned unchanged.
+func AutoAssignRecord(ctx context.Context, q Querier, item ClearanceItem, record ClearanceRecord) (ClearanceRecord, error) {
+	if item.ApproverPool == ApproverPoolNone {
+		return record, nil
+	}
+
+	members, err := q.ListItemPoolMembers(ctx, item.ID)
+	if err != nil {
+		return record, err
+	}
+
+	staffID, ok := PickAssignee(item.AssignmentStrategy, members)
+	if !ok {
+		return record, nil
+	}
+
+	return q.AssignClearanceRecord(ctx, AssignClearanceRecordParams{
+		ID:              record.ID,
+		AssignedStaffID: sql.NullInt64{Int64: staffID, Valid: true},
+	})
+}
+
+// ResponsibleApprover returns the staff member who decides a record: the
+// assignee for pooled items and the item's approver otherwise. It reports
+// false for pooled records nobody has claimed yet.
+func ResponsibleApprover(item GetRecordItemRow) (int64, bool) {
+	if item.ApproverPool == ApproverPoolNone {
+		return item.ApproverStaffID, true
+	}
+	return item.AssignedStaffID.Int64, item.AssignedStaffID.Valid
+}
@@ -0,0 +1,26 @@
+package db
+
+import "errors"
+
+// Allowed values of record_attachments.source. Only the record's own files
+// count towards an item's attachment requirement; files sent with a comment
+// do not.
+const (
+	AttachmentSourceRecord  = "record"
+	AttachmentSourceComment = "comment"
+)
+
+// ErrAttachmentRequired is returned when a record of an item that requires
+// an attachment is sent for review or approved before a file was uploaded.
+var ErrAttachmentRequired = errors.New("clearance item requires an attachment")
+
+// needsAttachment reports whether moving a record to status requires the
+// item's attachment to be present: the student marking it ready for review
+// and the approver approving it.
+func needsAttachment(status string) bool {
+	switch status {
+	case RecordStatusInReview, RecordStatusResubmitted, RecordStatusApproved:
+		return true
+	}
+	return false
+}
@@ -7,25 +7,28 @@ package db
 
 import (
 	"context"
+	"database/sql"
 )
 
 const createClearanceItem = `-- name: CreateClearanceItem :one
 INSERT INTO clearance_items (
     code, title, description, department_id,
     approver_staff_id, requires_attachment,
-    sequence, created_at
-) VALUES ($1,$2,$3,$4,$5,$6,$7,NOW())
-RETURNING id, code, title, description, department_id, approver_staff_id, requires_attachment, sequence, created_at
+    sequence, enforce_sequence, clearance_type_id, created_at
+) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,NOW())
+RETURNING id, code, title, description, department_id, approver_staff_id, requires_attachment, sequence, created_at, enforce_sequence, clearance_type_id, approver_pool, approver_group_id, assignment_strategy, required_signatures, rejection_policy
 `
 
 type CreateClearanceItemParams struct {
-	Code               string `json:"code"`
-	Title              string `json:"title"`
-	Description        string `json:"description"`
-	DepartmentID       int64  `json:"department_id"`
-	ApproverStaffID    int64  `json:"approver_staff_id"`
-	RequiresAttachment bool   `json:"requires_attachment"`
-	Sequence           int32  `json:"sequence"`
+	Code               string        `json:"code"`
+	Title              string        `json:"title"`
+	Description        string        `json:"description"`
+	DepartmentID       int64         `json:"department_id"`
+	ApproverStaffID    int64         `json:"approver_staff_id"`
+	RequiresAttachment bool          `json:"requires_attachment"`
+	Sequence           int32         `json:"sequence"`
+	EnforceSequence    bool          `json:"enforce_sequence"`
+	ClearanceTypeID    sql.NullInt64 `json:"clearance_type_id"`
 }
 
 func (q *Queries) CreateClearanceItem(ctx context.Context, arg CreateClearanceItemParams) (ClearanceItem, error) {
@@ -37,6 +40,8 @@ func (q *Queries) CreateClearanceItem(ctx context.Context, arg CreateClearanceIt
 		arg.ApproverStaffID,
 		arg.RequiresAttachment,
 		arg.Sequence,
+		arg.EnforceSequence,
+		arg.ClearanceTypeID,
 	)
 	var i ClearanceItem
 	err := row.Scan(
@@ -49,6 +54,13 @@ func (q *Queries) CreateClearanceItem(ctx context.Context, arg CreateClearanceIt
 		&i.RequiresAttachment,
 		&i.Sequence,
 		&i.CreatedAt,
+		&i.EnforceSequence,
+		&i.ClearanceTypeID,
+		&i.ApproverPool,
+		&i.ApproverGroupID,
+		&i.AssignmentStrategy,
+		&i.RequiredSignatures,
+		&i.RejectionPolicy,
 	)
 	return i, err
 }
@@ -63,7 +75,7 @@ func (q *Queries) DeleteClearanceItem(ctx context.Context, id int64) error {
 }
 
 const getClearanceItem = `-- name: GetClearanceItem :one
-SELECT id, code, title, description, department_id, approver_staff_id, requires_attachment, sequence, created_at FROM clearance_items WHERE id = $1 LIMIT 1
+SELECT id, code, title, description, department_id, approver_staff_id, requires_attachment, sequence, created_at, enforce_sequence, clearance_type_id, approver_pool, approver_group_id, assignment_strategy, required_signatures, rejection_policy FROM clearance_items WHERE id = $1 LIMIT 1
 `
 
 func (q *Queries) GetClearanceItem(ctx context.Context, id int64) (ClearanceItem, error) {
@@ -79,12 +91,19 @@ func (q *Queries) GetClearanceItem(ctx context.Context, id int64) (ClearanceItem
 		&i.RequiresAttachment,
 		&i.Sequence,
 		&i.CreatedAt,
+		&i.EnforceSequence,
+		&i.ClearanceTypeID,
+		&i.ApproverPool,
+		&i.ApproverGroupID,
+		&i.AssignmentStrategy,
+		&i.RequiredSignatures,
+		&i.RejectionPolicy,
 	)
 	return i, err
 }
 
 const listClearanceItems = `-- name: ListClearanceItems :many
-SELECT id, code, title, description, department_id, approver_staff_id, requires_attachment, sequence, created_at FROM clearance_items ORDER BY sequence
+SELECT id, code, title, description, department_id, approver_staff_id, requires_attachment, sequence, created_at, enforce_sequence, clearance_type_id, approver_pool, approver_group_id, assignment_strategy, required_signatures, rejection_policy FROM clearance_items ORDER BY sequence
 `
 
 func (q *Queries) ListClearanceItems(ctx context.Context) ([]ClearanceItem, error) {
@@ -106,6 +125,13 @@ func (q *Queries) ListClearanceItems(ctx context.Context) ([]ClearanceItem, erro
 			&i.RequiresAttachment,
 			&i.Sequence,
 			&i.CreatedAt,
+			&i.EnforceSequence,
+			&i.ClearanceTypeID,
+			&i.ApproverPool,
+			&i.ApproverGroupID,
+			&i.AssignmentStrategy,
+			&i.RequiredSignatures,
+			&i.RejectionPolicy,
 		); err != nil {
 			return nil, err
 		}
@@ -121,7 +147,7 @@ func (q *Queries) ListClearanceItems(ctx context.Context) ([]ClearanceItem, erro
 }
 
 const listItemsByDepartment = `-- name: ListItemsByDepartment :many
-SELECT id, code, title, description, department_id, approver_staff_id, requires_attachment, sequence, created_at FROM clearance_items WHERE department_id = $1 ORDER BY sequence
+SELECT id, code, title, description, department_id, approver_staff_id, requires_attachment, sequence, created_at, enforce_sequence, clearance_type_id, approver_pool, approver_group_id, assignment_strategy, required_signatures, rejection_policy FROM clearance_items WHERE department_id = $1 ORDER BY sequence
 `
 
 func (q *Queries) ListItemsByDepartment(ctx context.Context, departmentID int64) ([]ClearanceItem, error) {
@@ -143,6 +169,13 @@ func (q *Queries) ListItemsByDepartment(ctx context.Context, departmentID int64)
 			&i.RequiresAttachment,
 			&i.Sequence,
 			&i.CreatedAt,
+			&i.EnforceSequence,
+			&i.ClearanceTypeID,
+			&i.ApproverPool,
+			&i.ApproverGroupID,
+			&i.AssignmentStrategy,
+			&i.RequiredSignatures,
+			&i.RejectionPolicy,
 		); err != nil {
 			return nil, err
 		}
@@ -157,6 +190,87 @@ func (q *Queries) ListItemsByDepartment(ctx context.Context, departmentID int64)
 	return items, nil
 }
 
+const setClearanceItemPool = `-- name: SetClearanceItemPool :one
+UPDATE clearance_items SET
+    approver_pool = $2,
+    approver_group_id = $3,
+    assignment_strategy = $4
+WHERE id = $1 RETURNING id, code, title, description, department_id, approver_staff_id, requires_attachment, sequence, created_at, enforce_sequence, clearance_type_id, approver_pool, approver_group_id, assignment_strategy, required_signatures, rejection_policy
+`
+
+type SetClearanceItemPoolParams struct {
+	ID                 int64         `json:"id"`
+	ApproverPool       string        `json:"approver_pool"`
+	ApproverGroupID    sql.NullInt64 `json:"approver_group_id"`
+	AssignmentStrategy string        `json:"assignment_strategy"`
+}
+
+func (q *Queries) SetClearanceItemPool(ctx context.Context, arg SetClearanceItemPoolParams) (ClearanceItem, error) {
+	row := q.db.QueryRowContext(ctx, setClearanceItemPool,
+		arg.ID,
+		arg.ApproverPool,
+		arg.ApproverGroupID,
+		arg.AssignmentStrategy,
+	)
+	var i ClearanceItem
+	err := row.Scan(
+		&i.ID,
+		&i.Code,
+		&i.Title,
+		&i.Description,
+		&i.DepartmentID,
+		&i.ApproverStaffID,
+		&i.RequiresAttachment,
+		&i.Sequence,
+		&i.CreatedAt,
+		&i.EnforceSequence,
+		&i.ClearanceTypeID,
+		&i.ApproverPool,
+		&i.ApproverGroupID,
+		&i.AssignmentStrategy,
+		&i.RequiredSignatures,
+		&i.RejectionPolicy,
+	)
+	return i, err
+}
+
+const setClearanceItemSignaturePolicy = `-- name: SetClearanceItemSignaturePolicy :one
+UPDATE clearance_items SET
+    required_signatures = $2,
+    rejection_policy = $3
+WHERE id = $1 RETURNING id, code, title, description, department_id, approver_staff_id, requires_attachment, sequence, created_at, enforce_sequence, clearance_type_id, approver_pool, approver_group_id, assignment_strategy, required_signatures, rejection_policy
+`
+
+type SetClearanceItemSignaturePolicyParams struct {
+	ID                 int64  `json:"id"`
+	RequiredSignatures int32  `json:"required_signatures"`
+	RejectionPolicy    string `json:"rejection_policy"`
+}
+
+func (q *Queries) SetClearanceItemSignaturePolicy(ctx context.Context, arg SetClearanceItemSignaturePolicyParams) (ClearanceItem, error) {
+	row := q.db.QueryRowContext(ctx, setClearanceItemSignaturePolicy, arg.ID, arg.RequiredSignatures, arg.RejectionPolicy)
+	var i ClearanceItem
+	err := row.Scan(
+		&i.ID,
+		&i.Code,
+		&i.Title,
+		&i.Description,
+		&i.DepartmentID,
+		&i.ApproverStaffID,
+		&i.RequiresAttachment,
+		&i.Sequence,
+		&i.CreatedAt,
+		&i.EnforceSequence,
+		&i.ClearanceTypeID,
+		&i.ApproverPool,
+		&i.ApproverGroupID,
+		&i.AssignmentStrategy,
+		&i.RequiredSignatures,
+		&i.RejectionPolicy,
+	)
+	return i, err
+}
+
 const updateClearanceItem = `-- name: UpdateClearanceItem :one
 UPDATE clearance_items SET
     code = $1,
@@ -165,19 +279,23 @@ UPDATE clearance_items SET
     department_id = $4,
     approver_staff_id = $5,
     requires_attachment = $6,
-    sequence = $7
-WHERE id = $8 RETURNING id, code, title, description, department_id, approver_staff_id, requires_attachment, sequence, created_at
+    sequence = $7,
+    enforce_sequence = $8,
+    clearance_type_id = $9
+WHERE id = $10 RETURNING id, code, title, description, department_id, approver_staff_id, requires_attachment, sequence, created_at, enforce_sequence, clearance_type_id, approver_pool, approver_group_id, assignment_strategy, required_signatures, rejection_policy
 `
 
 type UpdateClearanceItemParams struct {
-	Code               string `json:"code"`
-	Title              string `json:"title"`
-	Description        string `json:"description"`
-	DepartmentID       int64  `json:"department_id"`
-	ApproverStaffID    int64  `json:"approver_staff_id"`
-	RequiresAttachment bool   `json:"requires_attachment"`
-	Sequence           int32  `json:"sequence"`
-	ID                 int64  `json:"id"`
+	Code               string        `json:"code"`
+	Title              string        `json:"title"`
+	Description        string        `json:"description"`
+	DepartmentID       int64         `json:"department_id"`
+	ApproverStaffID    int64         `json:"approver_staff_id"`
+	RequiresAttachment bool          `json:"requires_attachment"`
+	Sequence           int32         `json:"sequence"`
+	EnforceSequence    bool          `json:"enforce_sequence"`
+	ClearanceTypeID    sql.NullInt64 `json:"clearance_type_id"`
+	ID                 int64         `json:"id"`
 }
 
 func (q *Queries) UpdateClearanceItem(ctx context.Context, arg UpdateClearanceItemParams) (ClearanceItem, error) {
@@ -189,6 +307,8 @@ func (q *Queries) UpdateClearanceItem(ctx context.Context, arg UpdateClearanceIt
 		arg.ApproverStaffID,
 		arg.RequiresAttachment,
 		arg.Sequence,
+		arg.EnforceSequence,
+		arg.ClearanceTypeID,
 		arg.ID,
 	)
 	var i ClearanceItem
@@ -202,6 +322,13 @@ func (q *Queries) UpdateClearanceItem(ctx context.Context, arg UpdateClearanceIt
 		&i.RequiresAttachment,
 		&i.Sequence,
 		&i.CreatedAt,
+		&i.EnforceSequence,
+		&i.ClearanceTypeID,
+		&i.ApproverPool,
+		&i.ApproverGroupID,
+		&i.AssignmentStrategy,
+		&i.RequiredSignatures,
+		&i.RejectionPolicy,
 	)
 	return i, err
 }
@@ -0,0 +1,271 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// UpdateClearanceRecordStatusTxParams contains the input of UpdateClearanceRecordStatusTx.
+type UpdateClearanceRecordStatusTxParams struct {
+	RecordID      int64          `json:"record_id"`
+	Status        string         `json:"status"`
+	Note          string         `json:"note"`
+	HandledBy     sql.NullInt64  `json:"handled_by"` // empty for updates made by the student
+	AttachmentUrl sql.NullString `json:"attachment_url"`
+	// ActorRole and ActorID identify the caller in the record's history.
+	ActorRole string `json:"actor_role"`
+	ActorID   int64  `json:"actor_id"`
+	// OnBehalfOf is the approver a substitute decides for under a delegation.
+	OnBehalfOf sql.NullInt64 `json:"on_behalf_of"`
+	// SignerStaffID makes the decision a signature when the record's item
+	// requires more than one.
+	SignerStaffID sql.NullInt64 `json:"signer_staff_id"`
+	// Attachment, when set, is stored together with the status change.
+	Attachment *CreateRecordAttachmentParams `json:"attachment"`
+	// MaxResubmissions caps how often a record may be resubmitted; 0 means
+	// no limit.
+	MaxResubmissions int64 `json:"max_resubmissions"`
+	// Override skips the transition rules; only admins may set it.
+	Override bool `json:"override"`
+}
+
+// UpdateClearanceRecordStatusTxResult is the result of UpdateClearanceRecordStatusTx.
+type UpdateClearanceRecordStatusTxResult struct {
+	Previous ClearanceRecord      `json:"previous"`
+	Record   ClearanceRecord      `json:"record"`
+	Event    ClearanceRecordEvent `json:"event"`
+	// Attachment is the file stored with the update, if any.
+	Attachment RecordAttachment `json:"attachment"`
+	// Request is the parent request after its status was recomputed. It is
+	// empty when the record does not belong to a request.
+	Request ClearanceRequest `json:"request"`
+	// Cleared is true when this update made the parent request fully cleared.
+	Cleared bool `json:"cleared"`
+	// Unlocked lists records of the same request that became actionable
+	// because of this update.
+	Unlocked []ListRequestRecordItemsRow `json:"unlocked"`
+	// Signature is the signature stored for a multi-signature item.
+	Signature RecordSignature `json:"signature"`
+	// AwaitingSignatures is true when the signature was stored but the item
+	// needs more approvals; the record then keeps its status.
+	AwaitingSignatures bool `json:"awaiting_signatures"`
+	// RevokedCertificates lists the certificates revoked because this
+	// update reopened a cleared request.
+	RevokedCertificates []ClearanceCertificate `json:"revoked_certificates"`
+}
+
+// reopenedRevocationReason is recorded on certificates whose request stops
+// being cleared.
+const reopenedRevocationReason = "the clearance was reopened"
+
+// UpdateClearanceRecordStatusTx locks a clearance record, checks that the
+// requested status change is allowed, applies it, appends it to the
+// record's history and rolls the result up into the status of the parent
+// clearance request.
+func (store *SQLStore) UpdateClearanceRecordStatusTx(ctx context.Context, arg UpdateClearanceRecordStatusTxParams) (UpdateClearanceRecordStatusTxResult, error) {
+	var result UpdateClearanceRecordStatusTxResult
+
+	err := store.ExecTx(ctx, func(q Querier) error {
+		var err error
+		result, err = applyRecordStatus(ctx, q, arg)
+		return err
+	})
+
+	return result, err
+}
+
+// applyRecordStatus is the body of UpdateClearanceRecordStatusTx. It must
+// run inside a transaction; other transactions that change record statuses
+// use it directly.
+func applyRecordStatus(ctx context.Context, q Querier, arg UpdateClearanceRecordStatusTxParams) (UpdateClearanceRecordStatusTxResult, error) {
+	var result UpdateClearanceRecordStatusTxResult
+	var err error
+
+	result.Previous, err = q.GetClearanceRecordForUpdate(ctx, arg.RecordID)
+	if err != nil {
+		return result, err
+	}
+
+	err = ValidateRecordTransition(result.Previous.Status, arg.Status, arg.Override)
+	if err != nil {
+		return result, err
+	}
+
+	if arg.Status == RecordStatusResubmitted && arg.MaxResubmissions > 0 {
+		count, err := q.CountClearanceRecordEventsByStatus(ctx, CountClearanceRecordEventsByStatusParams{
+			RecordID:  arg.RecordID,
+			NewStatus: RecordStatusResubmitted,
+		})
+		if err != nil {
+			return result, err
+		}
+		if count >= arg.MaxResubmissions {
+			return result, ErrResubmissionLimitReached
+		}
+	}
+
+	if arg.Attachment != nil {
+		result.Attachment, err = q.CreateRecordAttachment(ctx, *arg.Attachment)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	// the attachment requirement holds even with an override
+	if needsAttachment(arg.Status) {
+		item, err := q.GetRecordItem(ctx, arg.RecordID)
+		if err != nil {
+			return result, err
+		}
+		if item.RequiresAttachment {
+			count, err := q.CountRecordAttachments(ctx, arg.RecordID)
+			if err != nil {
+				return result, err
+			}
+			if count == 0 {
+				return result, ErrAttachmentRequired
+			}
+		}
+	}
+
+	// records created by hand have no request and therefore no siblings
+	var siblings []ListRequestRecordItemsRow
+	if result.Previous.RequestID.Valid {
+		siblings, err = q.ListRequestRecordItems(ctx, result.Previous.RequestID)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	// waiving finishes an item as much as approving it, so both wait for
+	// the items before it
+	if IsFinalRecordStatus(arg.Status) && !arg.Override && result.Previous.RequestID.Valid &&
+		!ActionableRecords(siblings)[arg.RecordID] {
+		return result, ErrItemNotActionable
+	}
+
+	// like the attachment requirement, obligations hold even with an
+	// override, and waiving the record does not get round them; they are
+	// resolved or waived on their own
+	if IsFinalRecordStatus(arg.Status) {
+		if err := checkOpenObligations(ctx, q, arg.RecordID); err != nil {
+			return result, err
+		}
+	}
+
+	if arg.SignerStaffID.Valid {
+		var apply bool
+		result.Signature, apply, err = signClearanceRecord(ctx, q, arg)
+		if err != nil {
+			return result, err
+		}
+		if !apply {
+			result.Record = result.Previous
+			result.AwaitingSignatures = true
+			return result, nil
+		}
+	}
+
+	// keep the existing attachment unless a new one is supplied
+	attachment := arg.AttachmentUrl
+	if !attachment.Valid {
+		attachment = result.Previous.AttachmentUrl
+	}
+
+	// updates made by the student leave the last handler in place
+	handledBy, handledAt := arg.HandledBy, time.Now()
+	if !handledBy.Valid {
+		handledBy, handledAt = result.Previous.HandledBy, result.Previous.HandledAt
+	}
+
+	result.Record, err = q.UpdateClearanceRecordStatus(ctx, UpdateClearanceRecordStatusParams{
+		Status:        arg.Status,
+		Note:          arg.Note,
+		HandledBy:     handledBy,
+		HandledAt:     handledAt,
+		AttachmentUrl: attachment,
+		ID:            arg.RecordID,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	result.Event, err = q.CreateClearanceRecordEvent(ctx, CreateClearanceRecordEventParams{
+		RecordID:          arg.RecordID,
+		ActorRole:         arg.ActorRole,
+		ActorID:           arg.ActorID,
+		OldStatus:         sql.NullString{String: result.Previous.Status, Valid: true},
+		NewStatus:         arg.Status,
+		Note:              arg.Note,
+		OnBehalfOfStaffID: arg.OnBehalfOf,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	updated := make([]ListRequestRecordItemsRow, len(siblings))
+	copy(updated, siblings)
+	for i := range updated {
+		if updated[i].RecordID == arg.RecordID {
+			updated[i].Status = arg.Status
+		}
+	}
+	result.Unlocked = newlyActionable(siblings, updated)
+
+	var previousStatus string
+	result.Request, previousStatus, err = rollUpClearanceRequest(ctx, q, result.Record)
+	if err != nil {
+		return result, err
+	}
+	result.Cleared = previousStatus != RequestStatusCleared && result.Request.Status == RequestStatusCleared
+
+	// a certificate must not outlive the clearance it attests
+	if previousStatus == RequestStatusCleared && result.Request.Status != RequestStatusCleared {
+		result.RevokedCertificates, err = q.RevokeRequestCertificates(ctx, RevokeRequestCertificatesParams{
+			RequestID:        result.Request.ID,
+			RevocationReason: reopenedRevocationReason,
+		})
+	}
+	return result, err
+}
+
+// rollUpClearanceRequest recomputes the status of the request a record
+// belongs to. It also returns the status the request had before.
+func rollUpClearanceRequest(ctx context.Context, q Querier, record ClearanceRecord) (ClearanceRequest, string, error) {
+	// records created by hand may not have a parent request
+	if !record.RequestID.Valid {
+		return ClearanceRequest{}, "", nil
+	}
+
+	request, err := q.GetClearanceRequestForUpdate(ctx, record.RequestID.Int64)
+	if err != nil {
+		return ClearanceRequest{}, "", err
+	}
+
+	counts, err := q.GetRecordStatusCounts(ctx, record.RequestID)
+	if err != nil {
+		return ClearanceRequest{}, "", err
+	}
+
+	status := RollUpRequestStatus(counts)
+	if status == request.Status {
+		return request, request.Status, nil
+	}
+
+	completedAt := sql.NullTime{}
+	if status == RequestStatusCleared {
+		completedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	}
+
+	updated, err := q.UpdateClearanceRequestProgress(ctx, UpdateClearanceRequestProgressParams{
+		ID:          request.ID,
+		Status:      status,
+		CompletedAt: completedAt,
+	})
+	if err != nil {
+		return ClearanceRequest{}, "", err
+	}
+
+	return updated, request.Status, nil
+}
@@ -9,14 +9,83 @@ import (
 	"context"
 	"database/sql"
 	"time"
+
+	"github.com/lib/pq"
 )
 
+const assignClearanceRecord = `-- name: AssignClearanceRecord :one
+UPDATE clearance_records
+SET assigned_staff_id = $2, assigned_at = NOW()
+WHERE id = $1
+RETURNING id, student_id, clearance_item_id, session_id, status, note, handled_by, handled_at, attachment_url, updated_at, request_id, assigned_staff_id, assigned_at
+`
+
+type AssignClearanceRecordParams struct {
+	ID              int64         `json:"id"`
+	AssignedStaffID sql.NullInt64 `json:"assigned_staff_id"`
+}
+
+func (q *Queries) AssignClearanceRecord(ctx context.Context, arg AssignClearanceRecordParams) (ClearanceRecord, error) {
+	row := q.db.QueryRowContext(ctx, assignClearanceRecord, arg.ID, arg.AssignedStaffID)
+	var i ClearanceRecord
+	err := row.Scan(
+		&i.ID,
+		&i.StudentID,
+		&i.ClearanceItemID,
+		&i.SessionID,
+		&i.Status,
+		&i.Note,
+		&i.HandledBy,
+		&i.HandledAt,
+		&i.AttachmentUrl,
+		&i.UpdatedAt,
+		&i.RequestID,
+		&i.AssignedStaffID,
+		&i.AssignedAt,
+	)
+	return i, err
+}
+
+const claimClearanceRecord = `-- name: ClaimClearanceRecord :one
+UPDATE clearance_records
+SET assigned_staff_id = $2, assigned_at = NOW()
+WHERE id = $1 AND assigned_staff_id IS NULL
+RETURNING id, student_id, clearance_item_id, session_id, status, note, handled_by, handled_at, attachment_url, updated_at, request_id, assigned_staff_id, assigned_at
+`
+
+type ClaimClearanceRecordParams struct {
+	ID              int64         `json:"id"`
+	AssignedStaffID sql.NullInt64 `json:"assigned_staff_id"`
+}
+
+// Fails with no rows when someone else claimed the record first.
+func (q *Queries) ClaimClearanceRecord(ctx context.Context, arg ClaimClearanceRecordParams) (ClearanceRecord, error) {
+	row := q.db.QueryRowContext(ctx, claimClearanceRecord, arg.ID, arg.AssignedStaffID)
+	var i ClearanceRecord
+	err := row.Scan(
+		&i.ID,
+		&i.StudentID,
+		&i.ClearanceItemID,
+		&i.SessionID,
+		&i.Status,
+		&i.Note,
+		&i.HandledBy,
+		&i.HandledAt,
+		&i.AttachmentUrl,
+		&i.UpdatedAt,
+		&i.RequestID,
+		&i.AssignedStaffID,
+		&i.AssignedAt,
+	)
+	return i, err
+}
+
 const createClearanceRecord = `-- name: CreateClearanceRecord :one
 INSERT INTO clearance_records (
     student_id, clearance_item_id, session_id,
-    status, note, handled_by, attachment_url, updated_at
-) VALUES ($1,$2,$3,$4,$5,$6,$7,NOW())
-RETURNING id, student_id, clearance_item_id, session_id, status, note, handled_by, handled_at, attachment_url, updated_at
+    status, note, handled_by, attachment_url, request_id, updated_at
+) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,NOW())
+RETURNING id, student_id, clearance_item_id, session_id, status, note, handled_by, handled_at, attachment_url, updated_at, request_id, assigned_staff_id, assigned_at
 `
 
 type CreateClearanceRecordParams struct {
@@ -25,8 +94,9 @@ type CreateClearanceRecordParams struct {
 	SessionID       int64          `json:"session_id"`
 	Status          string         `json:"status"`
 	Note            string         `json:"note"`
-	HandledBy       int64          `json:"handled_by"`
+	HandledBy       sql.NullInt64  `json:"handled_by"`
 	AttachmentUrl   sql.NullString `json:"attachment_url"`
+	RequestID       sql.NullInt64  `json:"request_id"`
 }
 
 func (q *Queries) CreateClearanceRecord(ctx context.Context, arg CreateClearanceRecordParams) (ClearanceRecord, error) {
@@ -38,6 +108,7 @@ func (q *Queries) CreateClearanceRecord(ctx context.Context, arg CreateClearance
 		arg.Note,
 		arg.HandledBy,
 		arg.AttachmentUrl,
+		arg.RequestID,
 	)
 	var i ClearanceRecord
 	err := row.Scan(
@@ -51,25 +122,46 @@ func (q *Queries) CreateClearanceRecord(ctx context.Context, arg CreateClearance
 		&i.HandledAt,
 		&i.AttachmentUrl,
 		&i.UpdatedAt,
+		&i.RequestID,
+		&i.AssignedStaffID,
+		&i.AssignedAt,
 	)
 	return i, err
 }
 
-const deleteClearanceRecord = `-- name: DeleteClearanceRecord :exec
-DELETE FROM clearance_records WHERE id = $1
+const getClearanceRecord = `-- name: GetClearanceRecord :one
+SELECT id, student_id, clearance_item_id, session_id, status, note, handled_by, handled_at, attachment_url, updated_at, request_id, assigned_staff_id, assigned_at FROM clearance_records WHERE id = $1 LIMIT 1
 `
 
-func (q *Queries) DeleteClearanceRecord(ctx context.Context, id int64) error {
-	_, err := q.db.ExecContext(ctx, deleteClearanceRecord, id)
-	return err
+func (q *Queries) GetClearanceRecord(ctx context.Context, id int64) (ClearanceRecord, error) {
+	row := q.db.QueryRowContext(ctx, getClearanceRecord, id)
+	var i ClearanceRecord
+	err := row.Scan(
+		&i.ID,
+		&i.StudentID,
+		&i.ClearanceItemID,
+		&i.SessionID,
+		&i.Status,
+		&i.Note,
+		&i.HandledBy,
+		&i.HandledAt,
+		&i.AttachmentUrl,
+		&i.UpdatedAt,
+		&i.RequestID,
+		&i.AssignedStaffID,
+		&i.AssignedAt,
+	)
+	return i, err
 }
 
-const getClearanceRecord = `-- name: GetClearanceRecord :one
-SELECT id, student_id, clearance_item_id, session_id, status, note, handled_by, handled_at, attachment_url, updated_at FROM clearance_records WHERE id = $1 LIMIT 1
+const getClearanceRecordForUpdate = `-- name: GetClearanceRecordForUpdate :one
+SELECT id, student_id, clearance_item_id, session_id, status, note, handled_by, handled_at, attachment_url, updated_at, request_id, assigned_staff_id, assigned_at FROM clearance_records
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE
 `
 
-func (q *Queries) GetClearanceRecord(ctx context.Context, id int64) (ClearanceRecord, error) {
-	row := q.db.QueryRowContext(ctx, getClearanceRecord, id)
+func (q *Queries) GetClearanceRecordForUpdate(ctx context.Context, id int64) (ClearanceRecord, error) {
+	row := q.db.QueryRowContext(ctx, getClearanceRecordForUpdate, id)
 	var i ClearanceRecord
 	err := row.Scan(
 		&i.ID,
@@ -82,12 +174,250 @@ func (q *Queries) GetClearanceRecord(ctx context.Context, id int64) (ClearanceRe
 		&i.HandledAt,
 		&i.AttachmentUrl,
 		&i.UpdatedAt,
+		&i.RequestID,
+		&i.AssignedStaffID,
+		&i.AssignedAt,
+	)
+	return i, err
+}
+
+const getRecordItem = `-- name: GetRecordItem :one
+SELECT
+    cr.id AS record_id,
+    cr.student_id,
+    cr.session_id,
+    ci.id AS item_id,
+    ci.title,
+    ci.department_id,
+    COALESCE(si.approver_staff_id, ci.approver_staff_id) AS approver_staff_id,
+    ci.requires_attachment,
+    COALESCE(si.sequence, ci.sequence) AS sequence,
+    ci.approver_pool,
+    ci.approver_group_id,
+    cr.assigned_staff_id,
+    ci.required_signatures,
+    ci.rejection_policy
+FROM clearance_records cr
+JOIN clearance_items ci ON ci.id = cr.clearance_item_id
+LEFT JOIN session_items si
+  ON si.session_id = cr.session_id AND si.clearance_item_id = cr.clearance_item_id
+WHERE cr.id = $1
+`
+
+type GetRecordItemRow struct {
+	RecordID           int64         `json:"record_id"`
+	StudentID          int64         `json:"student_id"`
+	SessionID          int64         `json:"session_id"`
+	ItemID             int64         `json:"item_id"`
+	Title              string        `json:"title"`
+	DepartmentID       int64         `json:"department_id"`
+	ApproverStaffID    int64         `json:"approver_staff_id"`
+	RequiresAttachment bool          `json:"requires_attachment"`
+	Sequence           int32         `json:"sequence"`
+	ApproverPool       string        `json:"approver_pool"`
+	ApproverGroupID    sql.NullInt64 `json:"approver_group_id"`
+	AssignedStaffID    sql.NullInt64 `json:"assigned_staff_id"`
+	RequiredSignatures int32         `json:"required_signatures"`
+	RejectionPolicy    string        `json:"rejection_policy"`
+}
+
+func (q *Queries) GetRecordItem(ctx context.Context, id int64) (GetRecordItemRow, error) {
+	row := q.db.QueryRowContext(ctx, getRecordItem, id)
+	var i GetRecordItemRow
+	err := row.Scan(
+		&i.RecordID,
+		&i.StudentID,
+		&i.SessionID,
+		&i.ItemID,
+		&i.Title,
+		&i.DepartmentID,
+		&i.ApproverStaffID,
+		&i.RequiresAttachment,
+		&i.Sequence,
+		&i.ApproverPool,
+		&i.ApproverGroupID,
+		&i.AssignedStaffID,
+		&i.RequiredSignatures,
+		&i.RejectionPolicy,
+	)
+	return i, err
+}
+
+const getRecordStatusCounts = `-- name: GetRecordStatusCounts :one
+SELECT
+    COUNT(*) AS total,
+    COUNT(*) FILTER (WHERE status IN ('approved', 'waived')) AS done,
+    COUNT(*) FILTER (WHERE status = 'rejected') AS rejected
+FROM clearance_records
+WHERE request_id = $1
+`
+
+type GetRecordStatusCountsRow struct {
+	Total    int64 `json:"total"`
+	Done     int64 `json:"done"`
+	Rejected int64 `json:"rejected"`
+}
+
+func (q *Queries) GetRecordStatusCounts(ctx context.Context, requestID sql.NullInt64) (GetRecordStatusCountsRow, error) {
+	row := q.db.QueryRowContext(ctx, getRecordStatusCounts, requestID)
+	var i GetRecordStatusCountsRow
+	err := row.Scan(
+		&i.Total,
+		&i.Done,
+		&i.Rejected,
 	)
 	return i, err
 }
 
+const listApproverQueue = `-- name: ListApproverQueue :many
+SELECT
+    cr.id AS record_id,
+    cr.request_id,
+    cr.session_id,
+    cs.name AS session_name,
+    cr.status,
+    cr.updated_at AS waiting_since,
+    s.id AS student_id,
+    s.student_number,
+    s.first_name,
+    s.last_name,
+    s.department_id,
+    ci.id AS item_id,
+    ci.title,
+    resp.staff_id AS responsible_staff_id
+FROM clearance_records cr
+JOIN clearance_items ci ON ci.id = cr.clearance_item_id
+JOIN students s ON s.id = cr.student_id
+JOIN clearance_sessions cs ON cs.id = cr.session_id
+LEFT JOIN session_items si
+  ON si.session_id = cr.session_id AND si.clearance_item_id = cr.clearance_item_id
+CROSS JOIN LATERAL (
+    SELECT CASE WHEN ci.approver_pool = 'none'
+                THEN COALESCE(si.approver_staff_id, ci.approver_staff_id)
+                ELSE cr.assigned_staff_id END AS staff_id
+) resp
+WHERE (resp.staff_id = $1
+       OR EXISTS (
+           SELECT 1 FROM approver_delegations d
+           WHERE d.delegator_staff_id = resp.staff_id
+             AND d.substitute_staff_id = $1
+             AND CURRENT_DATE BETWEEN d.starts_on AND d.ends_on)
+       OR (resp.staff_id IS NULL AND (
+           (ci.approver_pool = 'department' AND ci.department_id = (
+               SELECT su.department_id FROM staff_users su
+               WHERE su.id = $1))
+           OR (ci.approver_pool = 'group' AND EXISTS (
+               SELECT 1 FROM approver_group_members m
+               WHERE m.group_id = ci.approver_group_id
+                 AND m.staff_id = $1))))
+       OR (ci.required_signatures > 1
+           AND EXISTS (
+               SELECT 1 FROM clearance_item_signers sg
+               JOIN staff_users su ON su.id = $1
+               WHERE sg.clearance_item_id = ci.id
+                 AND (sg.staff_id = su.id OR sg.role_id = su.role_id))
+           AND NOT EXISTS (
+               SELECT 1 FROM record_signatures rs
+               WHERE rs.record_id = cr.id
+                 AND rs.staff_id = $1
+                 AND rs.decision = 'approved'
+                 AND rs.invalidated_at IS NULL)))
+  AND cr.status = ANY($2::text[])
+  AND ($3::bigint IS NULL OR cr.session_id = $3::bigint)
+  AND ($4::bigint IS NULL OR s.department_id = $4::bigint)
+  AND ($5::timestamptz IS NULL OR cr.updated_at <= $5::timestamptz)
+  AND ($6::timestamptz IS NULL
+       OR (NOT $7::bool
+           AND (cr.updated_at, cr.id) > ($6::timestamptz, $8::bigint))
+       OR ($7::bool
+           AND (cr.updated_at, cr.id) < ($6::timestamptz, $8::bigint)))
+ORDER BY
+    CASE WHEN $7::bool THEN cr.updated_at END DESC,
+    CASE WHEN $7::bool THEN cr.id END DESC,
+    cr.updated_at,
+    cr.id
+LIMIT $9
+`
+
+type ListApproverQueueParams struct {
+	ApproverStaffID int64         `json:"approver_staff_id"`
+	Statuses        []string      `json:"statuses"`
+	SessionID       sql.NullInt64 `json:"session_id"`
+	DepartmentID    sql.NullInt64 `json:"department_id"`
+	WaitingBefore   sql.NullTime  `json:"waiting_before"`
+	CursorUpdatedAt sql.NullTime  `json:"cursor_updated_at"`
+	NewestFirst     bool          `json:"newest_first"`
+	CursorID        sql.NullInt64 `json:"cursor_id"`
+	PageLimit       int32         `json:"page_limit"`
+}
+
+type ListApproverQueueRow struct {
+	RecordID           int64         `json:"record_id"`
+	RequestID          sql.NullInt64 `json:"request_id"`
+	SessionID          int64         `json:"session_id"`
+	SessionName        string        `json:"session_name"`
+	Status             string        `json:"status"`
+	WaitingSince       time.Time     `json:"waiting_since"`
+	StudentID          int64         `json:"student_id"`
+	StudentNumber      string        `json:"student_number"`
+	FirstName          string        `json:"first_name"`
+	LastName           string        `json:"last_name"`
+	DepartmentID       int64         `json:"department_id"`
+	ItemID             int64         `json:"item_id"`
+	Title              string        `json:"title"`
+	ResponsibleStaffID sql.NullInt64 `json:"responsible_staff_id"`
+}
+
+func (q *Queries) ListApproverQueue(ctx context.Context, arg ListApproverQueueParams) ([]ListApproverQueueRow, error) {
+	rows, err := q.db.QueryContext(ctx, listApproverQueue,
+		arg.ApproverStaffID,
+		pq.Array(arg.Statuses),
+		arg.SessionID,
+		arg.DepartmentID,
+		arg.WaitingBefore,
+		arg.CursorUpdatedAt,
+		arg.NewestFirst,
+		arg.CursorID,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListApproverQueueRow{}
+	for rows.Next() {
+		var i ListApproverQueueRow
+		if err := rows.Scan(
+			&i.RecordID,
+			&i.RequestID,
+			&i.SessionID,
+			&i.SessionName,
+			&i.Status,
+			&i.WaitingSince,
+			&i.StudentID,
+			&i.StudentNumber,
+			&i.FirstName,
+			&i.LastName,
+			&i.DepartmentID,
+			&i.ItemID,
+			&i.Title,
+			&i.ResponsibleStaffID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listRecordsBySession = `-- name: ListRecordsBySession :many
-SELECT id, student_id, clearance_item_id, session_id, status, note, handled_by, handled_at, attachment_url, updated_at FROM clearance_records
+SELECT id, student_id, clearance_item_id, session_id, status, note, handled_by, handled_at, attachment_url, updated_at, request_id, assigned_staff_id, assigned_at FROM clearance_records
 WHERE session_id = $1
 ORDER BY student_id
 `
@@ -112,6 +442,63 @@ func (q *Queries) ListRecordsBySession(ctx context.Context, sessionID int64) ([]
 			&i.HandledAt,
 			&i.AttachmentUrl,
 			&i.UpdatedAt,
+			&i.RequestID,
+			&i.AssignedStaffID,
+			&i.AssignedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRecordsBySessionForStaff = `-- name: ListRecordsBySessionForStaff :many
+SELECT cr.id, cr.student_id, cr.clearance_item_id, cr.session_id, cr.status, cr.note, cr.handled_by, cr.handled_at, cr.attachment_url, cr.updated_at, cr.request_id, cr.assigned_staff_id, cr.assigned_at FROM clearance_records cr
+JOIN clearance_items ci ON ci.id = cr.clearance_item_id
+LEFT JOIN session_items si
+  ON si.session_id = cr.session_id AND si.clearance_item_id = cr.clearance_item_id
+WHERE cr.session_id = $1
+  AND (COALESCE(si.approver_staff_id, ci.approver_staff_id) = $2 OR cr.assigned_staff_id = $2
+       OR ci.department_id = $3)
+ORDER BY cr.student_id
+`
+
+type ListRecordsBySessionForStaffParams struct {
+	SessionID       int64 `json:"session_id"`
+	ApproverStaffID int64 `json:"approver_staff_id"`
+	DepartmentID    int64 `json:"department_id"`
+}
+
+func (q *Queries) ListRecordsBySessionForStaff(ctx context.Context, arg ListRecordsBySessionForStaffParams) ([]ClearanceRecord, error) {
+	rows, err := q.db.QueryContext(ctx, listRecordsBySessionForStaff, arg.SessionID, arg.ApproverStaffID, arg.DepartmentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ClearanceRecord{}
+	for rows.Next() {
+		var i ClearanceRecord
+		if err := rows.Scan(
+			&i.ID,
+			&i.StudentID,
+			&i.ClearanceItemID,
+			&i.SessionID,
+			&i.Status,
+			&i.Note,
+			&i.HandledBy,
+			&i.HandledAt,
+			&i.AttachmentUrl,
+			&i.UpdatedAt,
+			&i.RequestID,
+			&i.AssignedStaffID,
+			&i.AssignedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -127,7 +514,7 @@ func (q *Queries) ListRecordsBySession(ctx context.Context, sessionID int64) ([]
 }
 
 const listRecordsByStudent = `-- name: ListRecordsByStudent :many
-SELECT id, student_id, clearance_item_id, session_id, status, note, handled_by, handled_at, attachment_url, updated_at FROM clearance_records
+SELECT id, student_id, clearance_item_id, session_id, status, note, handled_by, handled_at, attachment_url, updated_at, request_id, assigned_staff_id, assigned_at FROM clearance_records
 WHERE student_id = $1
 ORDER BY clearance_item_id
 `
@@ -152,6 +539,215 @@ func (q *Queries) ListRecordsByStudent(ctx context.Context, studentID int64) ([]
 			&i.HandledAt,
 			&i.AttachmentUrl,
 			&i.UpdatedAt,
+			&i.RequestID,
+			&i.AssignedStaffID,
+			&i.AssignedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRecordsByStudentForStaff = `-- name: ListRecordsByStudentForStaff :many
+SELECT cr.id, cr.student_id, cr.clearance_item_id, cr.session_id, cr.status, cr.note, cr.handled_by, cr.handled_at, cr.attachment_url, cr.updated_at, cr.request_id, cr.assigned_staff_id, cr.assigned_at FROM clearance_records cr
+JOIN clearance_items ci ON ci.id = cr.clearance_item_id
+LEFT JOIN session_items si
+  ON si.session_id = cr.session_id AND si.clearance_item_id = cr.clearance_item_id
+WHERE cr.student_id = $1
+  AND (COALESCE(si.approver_staff_id, ci.approver_staff_id) = $2 OR cr.assigned_staff_id = $2
+       OR ci.department_id = $3)
+ORDER BY cr.clearance_item_id
+`
+
+type ListRecordsByStudentForStaffParams struct {
+	StudentID       int64 `json:"student_id"`
+	ApproverStaffID int64 `json:"approver_staff_id"`
+	DepartmentID    int64 `json:"department_id"`
+}
+
+func (q *Queries) ListRecordsByStudentForStaff(ctx context.Context, arg ListRecordsByStudentForStaffParams) ([]ClearanceRecord, error) {
+	rows, err := q.db.QueryContext(ctx, listRecordsByStudentForStaff, arg.StudentID, arg.ApproverStaffID, arg.DepartmentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ClearanceRecord{}
+	for rows.Next() {
+		var i ClearanceRecord
+		if err := rows.Scan(
+			&i.ID,
+			&i.StudentID,
+			&i.ClearanceItemID,
+			&i.SessionID,
+			&i.Status,
+			&i.Note,
+			&i.HandledBy,
+			&i.HandledAt,
+			&i.AttachmentUrl,
+			&i.UpdatedAt,
+			&i.RequestID,
+			&i.AssignedStaffID,
+			&i.AssignedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRecordsMissingAttachments = `-- name: ListRecordsMissingAttachments :many
+SELECT
+    cr.id AS record_id,
+    cr.request_id,
+    cr.session_id,
+    cr.status,
+    cr.updated_at,
+    s.id AS student_id,
+    s.student_number,
+    s.first_name,
+    s.last_name,
+    ci.id AS item_id,
+    ci.title,
+    COALESCE(si.approver_staff_id, ci.approver_staff_id) AS approver_staff_id
+FROM clearance_records cr
+JOIN clearance_items ci ON ci.id = cr.clearance_item_id
+JOIN students s ON s.id = cr.student_id
+LEFT JOIN session_items si
+  ON si.session_id = cr.session_id AND si.clearance_item_id = cr.clearance_item_id
+WHERE ci.requires_attachment
+  AND cr.status NOT IN ('approved', 'waived')
+  AND NOT EXISTS (SELECT 1 FROM record_attachments ra WHERE ra.record_id = cr.id AND ra.source = 'record')
+  AND ($1::bigint IS NULL
+       OR COALESCE(si.approver_staff_id, ci.approver_staff_id) = $1::bigint)
+  AND ($2::bigint IS NULL OR cr.session_id = $2::bigint)
+ORDER BY cr.updated_at, cr.id
+`
+
+type ListRecordsMissingAttachmentsParams struct {
+	ApproverStaffID sql.NullInt64 `json:"approver_staff_id"`
+	SessionID       sql.NullInt64 `json:"session_id"`
+}
+
+type ListRecordsMissingAttachmentsRow struct {
+	RecordID        int64         `json:"record_id"`
+	RequestID       sql.NullInt64 `json:"request_id"`
+	SessionID       int64         `json:"session_id"`
+	Status          string        `json:"status"`
+	UpdatedAt       time.Time     `json:"updated_at"`
+	StudentID       int64         `json:"student_id"`
+	StudentNumber   string        `json:"student_number"`
+	FirstName       string        `json:"first_name"`
+	LastName        string        `json:"last_name"`
+	ItemID          int64         `json:"item_id"`
+	Title           string        `json:"title"`
+	ApproverStaffID int64         `json:"approver_staff_id"`
+}
+
+func (q *Queries) ListRecordsMissingAttachments(ctx context.Context, arg ListRecordsMissingAttachmentsParams) ([]ListRecordsMissingAttachmentsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listRecordsMissingAttachments, arg.ApproverStaffID, arg.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListRecordsMissingAttachmentsRow{}
+	for rows.Next() {
+		var i ListRecordsMissingAttachmentsRow
+		if err := rows.Scan(
+			&i.RecordID,
+			&i.RequestID,
+			&i.SessionID,
+			&i.Status,
+			&i.UpdatedAt,
+			&i.StudentID,
+			&i.StudentNumber,
+			&i.FirstName,
+			&i.LastName,
+			&i.ItemID,
+			&i.Title,
+			&i.ApproverStaffID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRequestRecordItems = `-- name: ListRequestRecordItems :many
+SELECT
+    cr.id AS record_id,
+    cr.status,
+    ci.id AS item_id,
+    ci.title,
+    COALESCE(si.sequence, ci.sequence) AS sequence,
+    ci.enforce_sequence,
+    COALESCE(si.approver_staff_id, ci.approver_staff_id) AS approver_staff_id,
+    cr.assigned_staff_id,
+    ARRAY(
+        SELECT p.prerequisite_item_id FROM clearance_item_prerequisites p
+        WHERE p.item_id = ci.id
+        ORDER BY p.prerequisite_item_id
+    )::bigint[] AS prerequisite_item_ids
+FROM clearance_records cr
+JOIN clearance_items ci ON ci.id = cr.clearance_item_id
+LEFT JOIN session_items si
+  ON si.session_id = cr.session_id AND si.clearance_item_id = cr.clearance_item_id
+WHERE cr.request_id = $1
+ORDER BY COALESCE(si.sequence, ci.sequence), cr.id
+`
+
+type ListRequestRecordItemsRow struct {
+	RecordID            int64         `json:"record_id"`
+	Status              string        `json:"status"`
+	ItemID              int64         `json:"item_id"`
+	Title               string        `json:"title"`
+	Sequence            int32         `json:"sequence"`
+	EnforceSequence     bool          `json:"enforce_sequence"`
+	ApproverStaffID     int64         `json:"approver_staff_id"`
+	AssignedStaffID     sql.NullInt64 `json:"assigned_staff_id"`
+	PrerequisiteItemIds []int64       `json:"prerequisite_item_ids"`
+}
+
+func (q *Queries) ListRequestRecordItems(ctx context.Context, requestID sql.NullInt64) ([]ListRequestRecordItemsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listRequestRecordItems, requestID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListRequestRecordItemsRow{}
+	for rows.Next() {
+		var i ListRequestRecordItemsRow
+		if err := rows.Scan(
+			&i.RecordID,
+			&i.Status,
+			&i.ItemID,
+			&i.Title,
+			&i.Sequence,
+			&i.EnforceSequence,
+			&i.ApproverStaffID,
+			&i.AssignedStaffID,
+			pq.Array(&i.PrerequisiteItemIds),
 		); err != nil {
 			return nil, err
 		}
@@ -175,13 +771,13 @@ UPDATE clearance_records SET
     attachment_url = $5,
     updated_at = NOW()
 WHERE id = $6
-RETURNING id, student_id, clearance_item_id, session_id, status, note, handled_by, handled_at, attachment_url, updated_at
+RETURNING id, student_id, clearance_item_id, session_id, status, note, handled_by, handled_at, attachment_url, updated_at, request_id, assigned_staff_id, assigned_at
 `
 
 type UpdateClearanceRecordStatusParams struct {
 	Status        string         `json:"status"`
 	Note          string         `json:"note"`
-	HandledBy     int64          `json:"handled_by"`
+	HandledBy     sql.NullInt64  `json:"handled_by"`
 	HandledAt     time.Time      `json:"handled_at"`
 	AttachmentUrl sql.NullString `json:"attachment_url"`
 	ID            int64          `json:"id"`
@@ -208,6 +804,9 @@ func (q *Queries) UpdateClearanceRecordStatus(ctx context.Context, arg UpdateCle
 		&i.HandledAt,
 		&i.AttachmentUrl,
 		&i.UpdatedAt,
+		&i.RequestID,
+		&i.AssignedStaffID,
+		&i.AssignedAt,
 	)
 	return i, err
 }
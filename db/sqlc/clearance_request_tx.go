@@ -0,0 +1,168 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+// ErrDuplicateClearanceRequest is returned when a student already has a
+// clearance request of the same type for the session.
+var ErrDuplicateClearanceRequest = errors.New("clearance request already submitted")
+
+// uniqueViolation is the PostgreSQL error code for unique constraint violations.
+const uniqueViolation = "23505"
+
+// isUniqueViolation reports whether err is a unique constraint violation.
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == uniqueViolation
+}
+
+// SubmitClearanceRequestTxParams contains the input of SubmitClearanceRequestTx.
+type SubmitClearanceRequestTxParams struct {
+	StudentID       int64 `json:"student_id"`
+	SessionID       int64 `json:"session_id"`
+	ClearanceTypeID int64 `json:"clearance_type_id"`
+}
+
+// SubmitClearanceRequestTxResult is the result of SubmitClearanceRequestTx.
+type SubmitClearanceRequestTxResult struct {
+	Request ClearanceRequest  `json:"request"`
+	Records []ClearanceRecord `json:"records"`
+	Items   []ClearanceItem   `json:"items"`
+	// Actionable lists the items that can be worked on right away; items
+	// waiting for prerequisites or an enforced sequence are left out until
+	// they are unlocked.
+	Actionable []ClearanceItem `json:"actionable"`
+}
+
+// SubmitClearanceRequestTx creates a clearance request and one pending
+// clearance record per clearance item of the request's type that applies to
+// the student, in a
+// single transaction. Either the whole workflow is created or nothing is.
+func (store *SQLStore) SubmitClearanceRequestTx(ctx context.Context, arg SubmitClearanceRequestTxParams) (SubmitClearanceRequestTxResult, error) {
+	var result SubmitClearanceRequestTxResult
+
+	err := store.ExecTx(ctx, func(q Querier) error {
+		var err error
+
+		result.Request, err = q.CreateClearanceRequest(ctx, CreateClearanceRequestParams{
+			StudentID:       arg.StudentID,
+			SessionID:       arg.SessionID,
+			ClearanceTypeID: arg.ClearanceTypeID,
+		})
+		if err != nil {
+			if isUniqueViolation(err) {
+				return ErrDuplicateClearanceRequest
+			}
+			return err
+		}
+
+		student, err := q.GetStudent(ctx, arg.StudentID)
+		if err != nil {
+			return err
+		}
+
+		items, err := SessionClearanceItems(ctx, q, arg.SessionID)
+		if err != nil {
+			return err
+		}
+		items = filterItemsForType(items, arg.ClearanceTypeID)
+
+		itemIDs := make([]int64, 0, len(items))
+		for _, item := range items {
+			itemIDs = append(itemIDs, item.ID)
+		}
+
+		rules, err := q.ListRulesForItems(ctx, itemIDs)
+		if err != nil {
+			return err
+		}
+
+		// only items whose applicability rules match the student get a record
+		result.Items = filterItemsForStudent(items, rules, student)
+
+		result.Records = make([]ClearanceRecord, 0, len(result.Items))
+		for _, item := range result.Items {
+			record, err := q.CreateClearanceRecord(ctx, CreateClearanceRecordParams{
+				StudentID:       arg.StudentID,
+				ClearanceItemID: item.ID,
+				SessionID:       arg.SessionID,
+				Status:          RecordStatusPending,
+				RequestID:       sql.NullInt64{Int64: result.Request.ID, Valid: true},
+			})
+			if err != nil {
+				return err
+			}
+
+			record, err = AutoAssignRecord(ctx, q, item, record)
+			if err != nil {
+				return err
+			}
+
+			_, err = q.CreateClearanceRecordEvent(ctx, CreateClearanceRecordEventParams{
+				RecordID:  record.ID,
+				ActorRole: "student",
+				ActorID:   arg.StudentID,
+				NewStatus: record.Status,
+				Note:      "clearance request submitted",
+			})
+			if err != nil {
+				return err
+			}
+			result.Records = append(result.Records, record)
+		}
+
+		// the stored rows carry the prerequisite links of every item
+		rows, err := q.ListRequestRecordItems(ctx, sql.NullInt64{Int64: result.Request.ID, Valid: true})
+		if err != nil {
+			return err
+		}
+
+		actionable := ActionableRecords(rows)
+		for i, item := range result.Items {
+			if actionable[result.Records[i].ID] {
+				result.Actionable = append(result.Actionable, item)
+			}
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// SessionClearanceItems returns the clearance items that apply to a session:
+// the session's own item set with its sequence and approver overrides
+// applied, or every clearance item when the session has no item set.
+func SessionClearanceItems(ctx context.Context, q Querier, sessionID int64) ([]ClearanceItem, error) {
+	rows, err := q.ListSessionClearanceItems(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return q.ListClearanceItems(ctx)
+	}
+
+	items := make([]ClearanceItem, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, ClearanceItem(row))
+	}
+	return items, nil
+}
+
+// filterItemsForType keeps the items that belong to the given clearance type
+// or to no type at all; untyped items apply to every request.
+func filterItemsForType(items []ClearanceItem, typeID int64) []ClearanceItem {
+	filtered := make([]ClearanceItem, 0, len(items))
+	for _, item := range items {
+		if !item.ClearanceTypeID.Valid || item.ClearanceTypeID.Int64 == typeID {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered
+}
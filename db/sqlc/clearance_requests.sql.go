@@ -7,22 +7,36 @@ package db
 
 import (
 	"context"
+	"database/sql"
 )
 
+const countRequestsByType = `-- name: CountRequestsByType :one
+SELECT COUNT(*) FROM clearance_requests
+WHERE clearance_type_id = $1
+`
+
+func (q *Queries) CountRequestsByType(ctx context.Context, clearanceTypeID int64) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countRequestsByType, clearanceTypeID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createClearanceRequest = `-- name: CreateClearanceRequest :one
 INSERT INTO clearance_requests (
-    student_id, session_id
-) VALUES ($1, $2)
-RETURNING id, student_id, session_id, status, created_at
+    student_id, session_id, clearance_type_id
+) VALUES ($1, $2, $3)
+RETURNING id, student_id, session_id, status, created_at, completed_at, clearance_type_id
 `
 
 type CreateClearanceRequestParams struct {
-	StudentID int64 `json:"student_id"`
-	SessionID int64 `json:"session_id"`
+	StudentID       int64 `json:"student_id"`
+	SessionID       int64 `json:"session_id"`
+	ClearanceTypeID int64 `json:"clearance_type_id"`
 }
 
 func (q *Queries) CreateClearanceRequest(ctx context.Context, arg CreateClearanceRequestParams) (ClearanceRequest, error) {
-	row := q.db.QueryRowContext(ctx, createClearanceRequest, arg.StudentID, arg.SessionID)
+	row := q.db.QueryRowContext(ctx, createClearanceRequest, arg.StudentID, arg.SessionID, arg.ClearanceTypeID)
 	var i ClearanceRequest
 	err := row.Scan(
 		&i.ID,
@@ -30,12 +44,14 @@ func (q *Queries) CreateClearanceRequest(ctx context.Context, arg CreateClearanc
 		&i.SessionID,
 		&i.Status,
 		&i.CreatedAt,
+		&i.CompletedAt,
+		&i.ClearanceTypeID,
 	)
 	return i, err
 }
 
 const getClearanceRequest = `-- name: GetClearanceRequest :one
-SELECT id, student_id, session_id, status, created_at FROM clearance_requests WHERE id = $1 LIMIT 1
+SELECT id, student_id, session_id, status, created_at, completed_at, clearance_type_id FROM clearance_requests WHERE id = $1 LIMIT 1
 `
 
 func (q *Queries) GetClearanceRequest(ctx context.Context, id int64) (ClearanceRequest, error) {
@@ -47,12 +63,35 @@ func (q *Queries) GetClearanceRequest(ctx context.Context, id int64) (ClearanceR
 		&i.SessionID,
 		&i.Status,
 		&i.CreatedAt,
+		&i.CompletedAt,
+		&i.ClearanceTypeID,
+	)
+	return i, err
+}
+
+const getClearanceRequestForUpdate = `-- name: GetClearanceRequestForUpdate :one
+SELECT id, student_id, session_id, status, created_at, completed_at, clearance_type_id FROM clearance_requests
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetClearanceRequestForUpdate(ctx context.Context, id int64) (ClearanceRequest, error) {
+	row := q.db.QueryRowContext(ctx, getClearanceRequestForUpdate, id)
+	var i ClearanceRequest
+	err := row.Scan(
+		&i.ID,
+		&i.StudentID,
+		&i.SessionID,
+		&i.Status,
+		&i.CreatedAt,
+		&i.CompletedAt,
+		&i.ClearanceTypeID,
 	)
 	return i, err
 }
 
 const getStudentRequestForSession = `-- name: GetStudentRequestForSession :one
-SELECT id, student_id, session_id, status, created_at FROM clearance_requests
+SELECT id, student_id, session_id, status, created_at, completed_at, clearance_type_id FROM clearance_requests
 WHERE student_id = $1 AND session_id = $2
 LIMIT 1
 `
@@ -71,12 +110,14 @@ func (q *Queries) GetStudentRequestForSession(ctx context.Context, arg GetStuden
 		&i.SessionID,
 		&i.Status,
 		&i.CreatedAt,
+		&i.CompletedAt,
+		&i.ClearanceTypeID,
 	)
 	return i, err
 }
 
 const listAllRequests = `-- name: ListAllRequests :many
-SELECT id, student_id, session_id, status, created_at FROM clearance_requests
+SELECT id, student_id, session_id, status, created_at, completed_at, clearance_type_id FROM clearance_requests
 ORDER BY created_at DESC
 `
 
@@ -95,6 +136,8 @@ func (q *Queries) ListAllRequests(ctx context.Context) ([]ClearanceRequest, erro
 			&i.SessionID,
 			&i.Status,
 			&i.CreatedAt,
+			&i.CompletedAt,
+			&i.ClearanceTypeID,
 		); err != nil {
 			return nil, err
 		}
@@ -110,7 +153,7 @@ func (q *Queries) ListAllRequests(ctx context.Context) ([]ClearanceRequest, erro
 }
 
 const listRequestsByStudent = `-- name: ListRequestsByStudent :many
-SELECT id, student_id, session_id, status, created_at FROM clearance_requests
+SELECT id, student_id, session_id, status, created_at, completed_at, clearance_type_id FROM clearance_requests
 WHERE student_id = $1
 ORDER BY created_at DESC
 `
@@ -130,6 +173,8 @@ func (q *Queries) ListRequestsByStudent(ctx context.Context, studentID int64) ([
 			&i.SessionID,
 			&i.Status,
 			&i.CreatedAt,
+			&i.CompletedAt,
+			&i.ClearanceTypeID,
 		); err != nil {
 			return nil, err
 		}
@@ -144,6 +189,35 @@ func (q *Queries) ListRequestsByStudent(ctx context.Context, studentID int64) ([
 	return items, nil
 }
 
+const updateClearanceRequestProgress = `-- name: UpdateClearanceRequestProgress :one
+UPDATE clearance_requests
+SET status = $2,
+    completed_at = $3
+WHERE id = $1
+RETURNING id, student_id, session_id, status, created_at, completed_at, clearance_type_id
+`
+
+type UpdateClearanceRequestProgressParams struct {
+	ID          int64        `json:"id"`
+	Status      string       `json:"status"`
+	CompletedAt sql.NullTime `json:"completed_at"`
+}
+
+func (q *Queries) UpdateClearanceRequestProgress(ctx context.Context, arg UpdateClearanceRequestProgressParams) (ClearanceRequest, error) {
+	row := q.db.QueryRowContext(ctx, updateClearanceRequestProgress, arg.ID, arg.Status, arg.CompletedAt)
+	var i ClearanceRequest
+	err := row.Scan(
+		&i.ID,
+		&i.StudentID,
+		&i.SessionID,
+		&i.Status,
+		&i.CreatedAt,
+		&i.CompletedAt,
+		&i.ClearanceTypeID,
+	)
+	return i, err
+}
+
 const updateClearanceRequestStatus = `-- name: UpdateClearanceRequestStatus :exec
 UPDATE clearance_requests
 SET status = $1
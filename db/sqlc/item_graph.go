@@ -0,0 +1,159 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var (
+	// ErrPrerequisiteCycle is returned when prerequisite links would make an
+	// item wait for itself.
+	ErrPrerequisiteCycle = errors.New("clearance item prerequisites form a cycle")
+	// ErrPrerequisiteTypeMismatch is returned when an item requires an item
+	// of another clearance type, which never shares a request with it.
+	ErrPrerequisiteTypeMismatch = errors.New("clearance item prerequisite belongs to another clearance type")
+)
+
+// FindPrerequisiteCycle returns the item IDs along a cycle in the
+// prerequisite links, starting and ending with the same item, or nil when
+// the links form a graph without cycles.
+func FindPrerequisiteCycle(links []ClearanceItemPrerequisite) []int64 {
+	prerequisites := make(map[int64][]int64)
+	for _, link := range links {
+		prerequisites[link.ItemID] = append(prerequisites[link.ItemID], link.PrerequisiteItemID)
+	}
+
+	// walk the items in a fixed order so the same cycle is reported every time
+	itemIDs := make([]int64, 0, len(prerequisites))
+	for id, ids := range prerequisites {
+		itemIDs = append(itemIDs, id)
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	}
+	sort.Slice(itemIDs, func(i, j int) bool { return itemIDs[i] < itemIDs[j] })
+
+	const (
+		unvisited = iota
+		onPath
+		finished
+	)
+	state := make(map[int64]int)
+	var path []int64
+
+	var visit func(id int64) []int64
+	visit = func(id int64) []int64 {
+		state[id] = onPath
+		path = append(path, id)
+		for _, next := range prerequisites[id] {
+			switch state[next] {
+			case onPath:
+				for i, p := range path {
+					if p == next {
+						return append(append([]int64{}, path[i:]...), next)
+					}
+				}
+			case unvisited:
+				if cycle := visit(next); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[id] = finished
+		return nil
+	}
+
+	for _, id := range itemIDs {
+		if state[id] == unvisited {
+			if cycle := visit(id); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateItemGraph checks that the prerequisite links between items have
+// no cycle and that no item requires an item of another clearance type.
+// Untyped items apply to every type and may be linked to any item.
+func ValidateItemGraph(items []ClearanceItem, links []ClearanceItemPrerequisite) error {
+	if cycle := FindPrerequisiteCycle(links); cycle != nil {
+		steps := make([]string, len(cycle))
+		for i, id := range cycle {
+			steps[i] = fmt.Sprint(id)
+		}
+		return fmt.Errorf("%w: %s", ErrPrerequisiteCycle, strings.Join(steps, " -> "))
+	}
+
+	byID := make(map[int64]ClearanceItem, len(items))
+	for _, item := range items {
+		byID[item.ID] = item
+	}
+	for _, link := range links {
+		item, prerequisite := byID[link.ItemID], byID[link.PrerequisiteItemID]
+		if item.ClearanceTypeID.Valid && prerequisite.ClearanceTypeID.Valid &&
+			item.ClearanceTypeID.Int64 != prerequisite.ClearanceTypeID.Int64 {
+			return fmt.Errorf("%w: item %d requires item %d", ErrPrerequisiteTypeMismatch, item.ID, prerequisite.ID)
+		}
+	}
+	return nil
+}
+
+// CheckItemGraph validates the stored prerequisite graph. It locks the
+// links for the rest of the transaction so concurrent saves can't combine
+// into a cycle neither of them would make on its own.
+func CheckItemGraph(ctx context.Context, q Querier) error {
+	if err := q.LockItemPrerequisites(ctx); err != nil {
+		return err
+	}
+
+	items, err := q.ListClearanceItems(ctx)
+	if err != nil {
+		return err
+	}
+
+	links, err := q.ListAllItemPrerequisites(ctx)
+	if err != nil {
+		return err
+	}
+
+	return ValidateItemGraph(items, links)
+}
+
+// ReplaceItemPrerequisites replaces the prerequisites of an item and
+// validates the resulting graph. It must run inside a transaction so an
+// invalid graph is rolled back.
+func ReplaceItemPrerequisites(ctx context.Context, q Querier, itemID int64, prerequisiteIDs []int64) ([]ClearanceItemPrerequisite, error) {
+	if err := q.LockItemPrerequisites(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := q.DeleteItemPrerequisites(ctx, itemID); err != nil {
+		return nil, err
+	}
+
+	links := make([]ClearanceItemPrerequisite, 0, len(prerequisiteIDs))
+	seen := make(map[int64]bool, len(prerequisiteIDs))
+	for _, id := range prerequisiteIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		link, err := q.CreateItemPrerequisite(ctx, CreateItemPrerequisiteParams{
+			ItemID:             itemID,
+			PrerequisiteItemID: id,
+		})
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, link)
+	}
+
+	if err := CheckItemGraph(ctx, q); err != nil {
+		return nil, err
+	}
+	return links, nil
+}
@@ -0,0 +1,82 @@
+package db
+
+// Allowed values of clearance_item_rules.rule_type.
+const (
+	RuleTypeDepartment     = "department"
+	RuleTypeEnrollmentYear = "enrollment_year"
+	RuleTypeIncludeStudent = "include_student"
+	RuleTypeExcludeStudent = "exclude_student"
+)
+
+// IsValidRuleType reports whether ruleType is a known applicability rule type.
+func IsValidRuleType(ruleType string) bool {
+	switch ruleType {
+	case RuleTypeDepartment, RuleTypeEnrollmentYear, RuleTypeIncludeStudent, RuleTypeExcludeStudent:
+		return true
+	}
+	return false
+}
+
+// ItemAppliesToStudent evaluates an item's applicability rules for a student.
+//
+// An explicit exclude wins over everything and an explicit include wins over
+// the attribute rules. Department and enrollment year rules are ORed within
+// their type and ANDed across types. An item with only include rules applies
+// to the listed students alone, and an item without rules applies to all.
+func ItemAppliesToStudent(rules []ClearanceItemRule, student Student) bool {
+	var (
+		hasDepartment, matchDepartment bool
+		hasYear, matchYear             bool
+		hasInclude                     bool
+	)
+
+	for _, rule := range rules {
+		switch rule.RuleType {
+		case RuleTypeExcludeStudent:
+			if rule.StudentID.Int64 == student.ID {
+				return false
+			}
+		case RuleTypeIncludeStudent:
+			hasInclude = true
+		case RuleTypeDepartment:
+			hasDepartment = true
+			if rule.DepartmentID.Int64 == student.DepartmentID {
+				matchDepartment = true
+			}
+		case RuleTypeEnrollmentYear:
+			hasYear = true
+			if (!rule.MinEnrollmentYear.Valid || student.EnrollmentYear >= rule.MinEnrollmentYear.Int32) &&
+				(!rule.MaxEnrollmentYear.Valid || student.EnrollmentYear <= rule.MaxEnrollmentYear.Int32) {
+				matchYear = true
+			}
+		}
+	}
+
+	for _, rule := range rules {
+		if rule.RuleType == RuleTypeIncludeStudent && rule.StudentID.Int64 == student.ID {
+			return true
+		}
+	}
+
+	if hasDepartment || hasYear {
+		return (!hasDepartment || matchDepartment) && (!hasYear || matchYear)
+	}
+
+	return !hasInclude
+}
+
+// filterItemsForStudent keeps the items whose applicability rules match the student.
+func filterItemsForStudent(items []ClearanceItem, rules []ClearanceItemRule, student Student) []ClearanceItem {
+	byItem := make(map[int64][]ClearanceItemRule)
+	for _, rule := range rules {
+		byItem[rule.ClearanceItemID] = append(byItem[rule.ClearanceItemID], rule)
+	}
+
+	matched := make([]ClearanceItem, 0, len(items))
+	for _, item := range items {
+		if ItemAppliesToStudent(byItem[item.ID], student) {
+			matched = append(matched, item)
+		}
+	}
+	return matched
+}
@@ -20,18 +20,91 @@ type Admin struct {
 	CreatedAt      time.Time `json:"created_at"`
 }
 
+type ApproverDelegation struct {
+	ID                int64     `json:"id"`
+	DelegatorStaffID  int64     `json:"delegator_staff_id"`
+	SubstituteStaffID int64     `json:"substitute_staff_id"`
+	StartsOn          time.Time `json:"starts_on"`
+	EndsOn            time.Time `json:"ends_on"`
+	Reason            string    `json:"reason"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+type ApproverGroup struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type ApproverGroupMember struct {
+	GroupID      int64     `json:"group_id"`
+	StaffID      int64     `json:"staff_id"`
+	IsSupervisor bool      `json:"is_supervisor"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type ClearanceCertificate struct {
+	ID                int64        `json:"id"`
+	RequestID         int64        `json:"request_id"`
+	StudentID         int64        `json:"student_id"`
+	CertificateNumber string       `json:"certificate_number"`
+	StorageKey        string       `json:"storage_key"`
+	SizeBytes         int64        `json:"size_bytes"`
+	ChecksumSha256    string       `json:"checksum_sha256"`
+	Payload           string       `json:"payload"`
+	Signature         string       `json:"signature"`
+	KeyID             string       `json:"key_id"`
+	IssuedAt          time.Time    `json:"issued_at"`
+	VerificationCode  string       `json:"verification_code"`
+	RevokedAt         sql.NullTime `json:"revoked_at"`
+	RevocationReason  string       `json:"revocation_reason"`
+}
+
 type ClearanceItem struct {
-	ID                 int64     `json:"id"`
-	Code               string    `json:"code"`
-	Title              string    `json:"title"`
-	Description        string    `json:"description"`
-	DepartmentID       int64     `json:"department_id"`
-	ApproverStaffID    int64     `json:"approver_staff_id"`
-	RequiresAttachment bool      `json:"requires_attachment"`
-	Sequence           int32     `json:"sequence"`
+	ID                 int64         `json:"id"`
+	Code               string        `json:"code"`
+	Title              string        `json:"title"`
+	Description        string        `json:"description"`
+	DepartmentID       int64         `json:"department_id"`
+	ApproverStaffID    int64         `json:"approver_staff_id"`
+	RequiresAttachment bool          `json:"requires_attachment"`
+	Sequence           int32         `json:"sequence"`
+	CreatedAt          time.Time     `json:"created_at"`
+	EnforceSequence    bool          `json:"enforce_sequence"`
+	ClearanceTypeID    sql.NullInt64 `json:"clearance_type_id"`
+	ApproverPool       string        `json:"approver_pool"`
+	ApproverGroupID    sql.NullInt64 `json:"approver_group_id"`
+	AssignmentStrategy string        `json:"assignment_strategy"`
+	RequiredSignatures int32         `json:"required_signatures"`
+	RejectionPolicy    string        `json:"rejection_policy"`
+}
+
+type ClearanceItemPrerequisite struct {
+	ItemID             int64     `json:"item_id"`
+	PrerequisiteItemID int64     `json:"prerequisite_item_id"`
 	CreatedAt          time.Time `json:"created_at"`
 }
 
+type ClearanceItemRule struct {
+	ID                int64         `json:"id"`
+	ClearanceItemID   int64         `json:"clearance_item_id"`
+	RuleType          string        `json:"rule_type"`
+	DepartmentID      sql.NullInt64 `json:"department_id"`
+	MinEnrollmentYear sql.NullInt32 `json:"min_enrollment_year"`
+	MaxEnrollmentYear sql.NullInt32 `json:"max_enrollment_year"`
+	StudentID         sql.NullInt64 `json:"student_id"`
+	CreatedAt         time.Time     `json:"created_at"`
+}
+
+type ClearanceItemSigner struct {
+	ID              int64         `json:"id"`
+	ClearanceItemID int64         `json:"clearance_item_id"`
+	StaffID         sql.NullInt64 `json:"staff_id"`
+	RoleID          sql.NullInt64 `json:"role_id"`
+	CreatedAt       time.Time     `json:"created_at"`
+}
+
 type ClearanceRecord struct {
 	ID              int64          `json:"id"`
 	StudentID       int64          `json:"student_id"`
@@ -39,18 +112,35 @@ type ClearanceRecord struct {
 	SessionID       int64          `json:"session_id"`
 	Status          string         `json:"status"`
 	Note            string         `json:"note"`
-	HandledBy       int64          `json:"handled_by"`
+	HandledBy       sql.NullInt64  `json:"handled_by"`
 	HandledAt       time.Time      `json:"handled_at"`
 	AttachmentUrl   sql.NullString `json:"attachment_url"`
 	UpdatedAt       time.Time      `json:"updated_at"`
+	RequestID       sql.NullInt64  `json:"request_id"`
+	AssignedStaffID sql.NullInt64  `json:"assigned_staff_id"`
+	AssignedAt      sql.NullTime   `json:"assigned_at"`
+}
+
+type ClearanceRecordEvent struct {
+	ID                int64          `json:"id"`
+	RecordID          int64          `json:"record_id"`
+	ActorRole         string         `json:"actor_role"`
+	ActorID           int64          `json:"actor_id"`
+	OldStatus         sql.NullString `json:"old_status"`
+	NewStatus         string         `json:"new_status"`
+	Note              string         `json:"note"`
+	CreatedAt         time.Time      `json:"created_at"`
+	OnBehalfOfStaffID sql.NullInt64  `json:"on_behalf_of_staff_id"`
 }
 
 type ClearanceRequest struct {
-	ID        int64     `json:"id"`
-	StudentID int64     `json:"student_id"`
-	SessionID int64     `json:"session_id"`
-	Status    string    `json:"status"`
-	CreatedAt time.Time `json:"created_at"`
+	ID              int64        `json:"id"`
+	StudentID       int64        `json:"student_id"`
+	SessionID       int64        `json:"session_id"`
+	Status          string       `json:"status"`
+	CreatedAt       time.Time    `json:"created_at"`
+	CompletedAt     sql.NullTime `json:"completed_at"`
+	ClearanceTypeID int64        `json:"clearance_type_id"`
 }
 
 type ClearanceSession struct {
@@ -62,6 +152,14 @@ type ClearanceSession struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
+type ClearanceType struct {
+	ID          int64     `json:"id"`
+	Code        string    `json:"code"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
 type Department struct {
 	ID        int64     `json:"id"`
 	Code      string    `json:"code"`
@@ -78,11 +176,70 @@ type Notification struct {
 	CreatedAt          time.Time     `json:"created_at"`
 }
 
+type Payment struct {
+	ID                int64        `json:"id"`
+	ObligationID      int64        `json:"obligation_id"`
+	StudentID         int64        `json:"student_id"`
+	Reference         string       `json:"reference"`
+	Provider          string       `json:"provider"`
+	ProviderPaymentID string       `json:"provider_payment_id"`
+	CheckoutUrl       string       `json:"checkout_url"`
+	Amount            int64        `json:"amount"`
+	Currency          string       `json:"currency"`
+	Status            string       `json:"status"`
+	FailureReason     string       `json:"failure_reason"`
+	ConfirmedAt       sql.NullTime `json:"confirmed_at"`
+	CreatedAt         time.Time    `json:"created_at"`
+	UpdatedAt         time.Time    `json:"updated_at"`
+}
+
+type RecordAttachment struct {
+	ID             int64     `json:"id"`
+	RecordID       int64     `json:"record_id"`
+	StorageKey     string    `json:"storage_key"`
+	FileName       string    `json:"file_name"`
+	ContentType    string    `json:"content_type"`
+	SizeBytes      int64     `json:"size_bytes"`
+	ChecksumSha256 string    `json:"checksum_sha256"`
+	UploadedByRole string    `json:"uploaded_by_role"`
+	UploadedByID   int64     `json:"uploaded_by_id"`
+	CreatedAt      time.Time `json:"created_at"`
+	Source         string    `json:"source"`
+}
+
+type RecordComment struct {
+	ID           int64         `json:"id"`
+	RecordID     int64         `json:"record_id"`
+	AuthorRole   string        `json:"author_role"`
+	AuthorID     int64         `json:"author_id"`
+	Body         string        `json:"body"`
+	AttachmentID sql.NullInt64 `json:"attachment_id"`
+	CreatedAt    time.Time     `json:"created_at"`
+}
+
+type RecordSignature struct {
+	ID            int64        `json:"id"`
+	RecordID      int64        `json:"record_id"`
+	StaffID       int64        `json:"staff_id"`
+	Decision      string       `json:"decision"`
+	Note          string       `json:"note"`
+	SignedAt      time.Time    `json:"signed_at"`
+	InvalidatedAt sql.NullTime `json:"invalidated_at"`
+}
+
 type Role struct {
 	ID   int64  `json:"id"`
 	Name string `json:"name"`
 }
 
+type SessionItem struct {
+	SessionID       int64         `json:"session_id"`
+	ClearanceItemID int64         `json:"clearance_item_id"`
+	Sequence        sql.NullInt32 `json:"sequence"`
+	ApproverStaffID sql.NullInt64 `json:"approver_staff_id"`
+	CreatedAt       time.Time     `json:"created_at"`
+}
+
 type StaffUser struct {
 	ID           int64     `json:"id"`
 	Username     string    `json:"username"`
@@ -105,3 +262,27 @@ type Student struct {
 	EnrollmentYear int32     `json:"enrollment_year"`
 	CreatedAt      time.Time `json:"created_at"`
 }
+
+type StudentCredential struct {
+	StudentID      int64     `json:"student_id"`
+	HashedPassword string    `json:"hashed_password"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+type StudentObligation struct {
+	ID             int64          `json:"id"`
+	StudentID      int64          `json:"student_id"`
+	DepartmentID   int64          `json:"department_id"`
+	ObligationType string         `json:"obligation_type"`
+	Description    string         `json:"description"`
+	Amount         int64          `json:"amount"`
+	Currency       string         `json:"currency"`
+	Status         string         `json:"status"`
+	RaisedByRole   string         `json:"raised_by_role"`
+	RaisedByID     int64          `json:"raised_by_id"`
+	ResolvedByRole sql.NullString `json:"resolved_by_role"`
+	ResolvedByID   sql.NullInt64  `json:"resolved_by_id"`
+	ResolutionNote string         `json:"resolution_note"`
+	ResolvedAt     sql.NullTime   `json:"resolved_at"`
+	CreatedAt      time.Time      `json:"created_at"`
+}
@@ -0,0 +1,56 @@
+package db
+
+import (
+	"context"
+	"errors"
+)
+
+// Allowed values of student_obligations.obligation_type.
+const (
+	ObligationTypeBook      = "book"
+	ObligationTypeEquipment = "equipment"
+	ObligationTypeKey       = "key"
+	ObligationTypeFee       = "fee"
+	ObligationTypeOther     = "other"
+)
+
+// Allowed values of student_obligations.status.
+const (
+	ObligationStatusOpen     = "open"
+	ObligationStatusResolved = "resolved"
+	ObligationStatusWaived   = "waived"
+)
+
+// ErrOpenObligations is returned when a record is approved or waived while
+// the student still owes the item's department something.
+var ErrOpenObligations = errors.New("student has open obligations with the item's department")
+
+// IsValidObligationType reports whether t is one of the known obligation types.
+func IsValidObligationType(t string) bool {
+	switch t {
+	case ObligationTypeBook, ObligationTypeEquipment, ObligationTypeKey, ObligationTypeFee, ObligationTypeOther:
+		return true
+	}
+	return false
+}
+
+// checkOpenObligations returns ErrOpenObligations when the student of a
+// record has open obligations with the department of the record's item.
+func checkOpenObligations(ctx context.Context, q Querier, recordID int64) error {
+	item, err := q.GetRecordItem(ctx, recordID)
+	if err != nil {
+		return err
+	}
+
+	open, err := q.CountOpenDepartmentObligations(ctx, CountOpenDepartmentObligationsParams{
+		StudentID:    item.StudentID,
+		DepartmentID: item.DepartmentID,
+	})
+	if err != nil {
+		return err
+	}
+	if open > 0 {
+		return ErrOpenObligations
+	}
+	return nil
+}
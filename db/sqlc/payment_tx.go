@@ -0,0 +1,136 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// Allowed values of payments.status.
+const (
+	PaymentStatusPending   = "pending"
+	PaymentStatusSucceeded = "succeeded"
+	PaymentStatusFailed    = "failed"
+)
+
+// ConfirmPaymentTxParams contains the input of ConfirmPaymentTx, taken
+// from a verified provider callback.
+type ConfirmPaymentTxParams struct {
+	Reference         string `json:"reference"`
+	ProviderPaymentID string `json:"provider_payment_id"`
+	Succeeded         bool   `json:"succeeded"`
+	Amount            int64  `json:"amount"`
+	Currency          string `json:"currency"`
+}
+
+// ConfirmPaymentTxResult is the result of ConfirmPaymentTx.
+type ConfirmPaymentTxResult struct {
+	Payment Payment `json:"payment"`
+	// Duplicate is true when the payment was completed by an earlier
+	// callback; nothing was changed.
+	Duplicate bool `json:"duplicate"`
+	// Obligation is the obligation the payment resolved. It is empty when
+	// the payment failed or the obligation was closed in the meantime.
+	Obligation StudentObligation `json:"obligation"`
+	// Unapplied is true when the payment succeeded but its obligation was
+	// already resolved or waived; the money has to be refunded.
+	Unapplied bool `json:"unapplied"`
+	// Advanced lists the records that moved forward because the student no
+	// longer owes the department anything.
+	Advanced []UpdateClearanceRecordStatusTxResult `json:"advanced"`
+}
+
+// ConfirmPaymentTx completes a pending payment. A successful payment of the
+// expected amount resolves its obligation, and once the student owes the
+// department nothing else, their pending records of the department's items
+// go to review. Rejected records are left for the student to resubmit.
+// Callbacks for completed payments are ignored, so providers may deliver
+// them more than once.
+func (store *SQLStore) ConfirmPaymentTx(ctx context.Context, arg ConfirmPaymentTxParams) (ConfirmPaymentTxResult, error) {
+	var result ConfirmPaymentTxResult
+
+	err := store.ExecTx(ctx, func(q Querier) error {
+		payment, err := q.GetPaymentByReferenceForUpdate(ctx, arg.Reference)
+		if err != nil {
+			return err
+		}
+		if payment.Status != PaymentStatusPending {
+			result.Payment, result.Duplicate = payment, true
+			return nil
+		}
+
+		status, reason := PaymentStatusFailed, "declined by the payment provider"
+		if arg.Succeeded {
+			status, reason = PaymentStatusSucceeded, ""
+			if arg.Amount != payment.Amount || arg.Currency != payment.Currency {
+				status = PaymentStatusFailed
+				reason = fmt.Sprintf("paid %d %s but %d %s was due", arg.Amount, arg.Currency, payment.Amount, payment.Currency)
+			}
+		}
+
+		result.Payment, err = q.CompletePayment(ctx, CompletePaymentParams{
+			ID:                payment.ID,
+			Status:            status,
+			ProviderPaymentID: arg.ProviderPaymentID,
+			FailureReason:     reason,
+		})
+		if err != nil || status != PaymentStatusSucceeded {
+			return err
+		}
+
+		result.Obligation, err = q.ResolveStudentObligation(ctx, ResolveStudentObligationParams{
+			ID:             payment.ObligationID,
+			Status:         ObligationStatusResolved,
+			ResolvedByRole: sql.NullString{String: "payment", Valid: true},
+			ResolvedByID:   sql.NullInt64{Int64: payment.ID, Valid: true},
+			ResolutionNote: "paid online, reference " + payment.Reference,
+		})
+		if err != nil {
+			// the obligation was closed while the payment was open; the
+			// payment stands but is reported so it can be refunded
+			if errors.Is(err, sql.ErrNoRows) {
+				result.Obligation, result.Unapplied = StudentObligation{}, true
+				return nil
+			}
+			return err
+		}
+
+		open, err := q.CountOpenDepartmentObligations(ctx, CountOpenDepartmentObligationsParams{
+			StudentID:    result.Obligation.StudentID,
+			DepartmentID: result.Obligation.DepartmentID,
+		})
+		if err != nil || open > 0 {
+			return err
+		}
+
+		records, err := q.ListStudentRecordsWaitingInDepartment(ctx, ListStudentRecordsWaitingInDepartmentParams{
+			StudentID:    result.Obligation.StudentID,
+			DepartmentID: result.Obligation.DepartmentID,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, record := range records {
+			advanced, err := applyRecordStatus(ctx, q, UpdateClearanceRecordStatusTxParams{
+				RecordID:  record.ID,
+				Status:    RecordStatusInReview,
+				Note:      "obligation paid online, reference " + payment.Reference,
+				ActorRole: "payment",
+				ActorID:   payment.ID,
+			})
+			if err != nil {
+				// records still missing a required attachment wait for the student
+				if errors.Is(err, ErrAttachmentRequired) {
+					continue
+				}
+				return err
+			}
+			result.Advanced = append(result.Advanced, advanced)
+		}
+		return nil
+	})
+
+	return result, err
+}
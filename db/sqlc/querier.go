@@ -12,35 +12,82 @@ import (
 type Querier interface {
 	ActivateSession(ctx context.Context, id int64) error
 	AdminExistsByUsername(ctx context.Context, username string) (bool, error)
+	AssignClearanceRecord(ctx context.Context, arg AssignClearanceRecordParams) (ClearanceRecord, error)
+	ClaimClearanceRecord(ctx context.Context, arg ClaimClearanceRecordParams) (ClearanceRecord, error)
+	CompletePayment(ctx context.Context, arg CompletePaymentParams) (Payment, error)
+	CopySessionItems(ctx context.Context, arg CopySessionItemsParams) (int64, error)
+	CountClearanceRecordEventsByStatus(ctx context.Context, arg CountClearanceRecordEventsByStatusParams) (int64, error)
+	CountItemsUsingApproverGroup(ctx context.Context, approverGroupID sql.NullInt64) (int64, error)
+	CountOpenDepartmentObligations(ctx context.Context, arg CountOpenDepartmentObligationsParams) (int64, error)
+	CountOverlappingDelegations(ctx context.Context, arg CountOverlappingDelegationsParams) (int64, error)
+	CountRecordAttachments(ctx context.Context, recordID int64) (int64, error)
+	CountRequestCertificates(ctx context.Context, requestID int64) (int64, error)
+	CountRequestsByType(ctx context.Context, clearanceTypeID int64) (int64, error)
+	CountSessionItems(ctx context.Context, sessionID int64) (int64, error)
 	CreateAdmin(ctx context.Context, arg CreateAdminParams) (Admin, error)
+	CreateApproverDelegation(ctx context.Context, arg CreateApproverDelegationParams) (ApproverDelegation, error)
+	CreateApproverGroup(ctx context.Context, arg CreateApproverGroupParams) (ApproverGroup, error)
+	CreateClearanceCertificate(ctx context.Context, arg CreateClearanceCertificateParams) (ClearanceCertificate, error)
 	CreateClearanceItem(ctx context.Context, arg CreateClearanceItemParams) (ClearanceItem, error)
+	CreateClearanceItemRule(ctx context.Context, arg CreateClearanceItemRuleParams) (ClearanceItemRule, error)
+	CreateClearanceItemSigner(ctx context.Context, arg CreateClearanceItemSignerParams) (ClearanceItemSigner, error)
 	CreateClearanceRecord(ctx context.Context, arg CreateClearanceRecordParams) (ClearanceRecord, error)
+	CreateClearanceRecordEvent(ctx context.Context, arg CreateClearanceRecordEventParams) (ClearanceRecordEvent, error)
 	CreateClearanceRequest(ctx context.Context, arg CreateClearanceRequestParams) (ClearanceRequest, error)
+	CreateClearanceType(ctx context.Context, arg CreateClearanceTypeParams) (ClearanceType, error)
 	CreateDepartment(ctx context.Context, arg CreateDepartmentParams) (Department, error)
+	CreateItemPrerequisite(ctx context.Context, arg CreateItemPrerequisiteParams) (ClearanceItemPrerequisite, error)
 	CreateNotification(ctx context.Context, arg CreateNotificationParams) (Notification, error)
+	CreatePayment(ctx context.Context, arg CreatePaymentParams) (Payment, error)
+	CreateRecordAttachment(ctx context.Context, arg CreateRecordAttachmentParams) (RecordAttachment, error)
+	CreateRecordComment(ctx context.Context, arg CreateRecordCommentParams) (RecordComment, error)
+	CreateRecordSignature(ctx context.Context, arg CreateRecordSignatureParams) (RecordSignature, error)
 	CreateRole(ctx context.Context, name string) (Role, error)
 	CreateSession(ctx context.Context, arg CreateSessionParams) (ClearanceSession, error)
 	CreateStaffUser(ctx context.Context, arg CreateStaffUserParams) (StaffUser, error)
 	CreateStudent(ctx context.Context, arg CreateStudentParams) (Student, error)
+	CreateStudentObligation(ctx context.Context, arg CreateStudentObligationParams) (StudentObligation, error)
 	DeactivateAllSessions(ctx context.Context) error
 	DeleteAdmin(ctx context.Context, id int64) error
+	DeleteApproverDelegation(ctx context.Context, id int64) error
+	DeleteApproverGroup(ctx context.Context, id int64) error
+	DeleteApproverGroupMember(ctx context.Context, arg DeleteApproverGroupMemberParams) (int64, error)
 	DeleteClearanceItem(ctx context.Context, id int64) error
-	DeleteClearanceRecord(ctx context.Context, id int64) error
+	DeleteClearanceItemRule(ctx context.Context, arg DeleteClearanceItemRuleParams) error
+	DeleteClearanceItemSigners(ctx context.Context, clearanceItemID int64) error
+	DeleteClearanceType(ctx context.Context, id int64) error
 	DeleteDepartment(ctx context.Context, id int64) error
+	DeleteItemPrerequisites(ctx context.Context, itemID int64) error
 	DeleteNotification(ctx context.Context, id int64) error
+	DeleteRecordAttachment(ctx context.Context, id int64) error
 	DeleteRole(ctx context.Context, id int64) error
 	DeleteSession(ctx context.Context, id int64) error
+	DeleteSessionItem(ctx context.Context, arg DeleteSessionItemParams) error
 	DeleteStaffUser(ctx context.Context, id int64) error
 	DeleteStudent(ctx context.Context, id int64) error
+	GetActiveObligationPayment(ctx context.Context, obligationID int64) (Payment, error)
 	GetActiveSession(ctx context.Context) (ClearanceSession, error)
 	GetAdmin(ctx context.Context, id int64) (Admin, error)
 	GetAdminByUsername(ctx context.Context, username string) (Admin, error)
+	GetApproverDelegation(ctx context.Context, id int64) (ApproverDelegation, error)
+	GetApproverGroup(ctx context.Context, id int64) (ApproverGroup, error)
+	GetCertificateByRequest(ctx context.Context, requestID int64) (ClearanceCertificate, error)
+	GetCertificateByVerificationCode(ctx context.Context, verificationCode string) (ClearanceCertificate, error)
 	GetClearanceItem(ctx context.Context, id int64) (ClearanceItem, error)
 	GetClearanceRecord(ctx context.Context, id int64) (ClearanceRecord, error)
+	GetClearanceRecordForUpdate(ctx context.Context, id int64) (ClearanceRecord, error)
 	GetClearanceRequest(ctx context.Context, id int64) (ClearanceRequest, error)
+	GetClearanceRequestForUpdate(ctx context.Context, id int64) (ClearanceRequest, error)
+	GetClearanceType(ctx context.Context, id int64) (ClearanceType, error)
+	GetClearanceTypeByCode(ctx context.Context, code string) (ClearanceType, error)
 	GetDepartment(ctx context.Context, id int64) (Department, error)
 	GetDepartmentByCode(ctx context.Context, code string) (Department, error)
 	GetNotification(ctx context.Context, id int64) (Notification, error)
+	GetPaymentByReference(ctx context.Context, reference string) (Payment, error)
+	GetPaymentByReferenceForUpdate(ctx context.Context, reference string) (Payment, error)
+	GetRecordAttachment(ctx context.Context, id int64) (RecordAttachment, error)
+	GetRecordItem(ctx context.Context, id int64) (GetRecordItemRow, error)
+	GetRecordStatusCounts(ctx context.Context, requestID sql.NullInt64) (GetRecordStatusCountsRow, error)
 	GetRole(ctx context.Context, id int64) (Role, error)
 	GetRoleByName(ctx context.Context, name string) (Role, error)
 	GetSession(ctx context.Context, id int64) (ClearanceSession, error)
@@ -50,33 +97,79 @@ type Querier interface {
 	GetStudent(ctx context.Context, id int64) (Student, error)
 	GetStudentByStudentNumber(ctx context.Context, studentNumber string) (Student, error)
 	GetStudentByStudentNumberForUpdate(ctx context.Context, studentNumber string) (Student, error)
+	GetStudentCredential(ctx context.Context, studentID int64) (StudentCredential, error)
 	GetStudentForUpdate(ctx context.Context, id int64) (Student, error)
+	GetStudentObligation(ctx context.Context, id int64) (StudentObligation, error)
+	GetStudentObligationForUpdate(ctx context.Context, id int64) (StudentObligation, error)
 	GetStudentRequestForSession(ctx context.Context, arg GetStudentRequestForSessionParams) (ClearanceRequest, error)
+	HasActiveDelegation(ctx context.Context, arg HasActiveDelegationParams) (bool, error)
+	InvalidateRecordSignatures(ctx context.Context, recordID int64) ([]RecordSignature, error)
+	InvalidateStaffSignature(ctx context.Context, arg InvalidateStaffSignatureParams) error
+	IsApproverGroupSupervisor(ctx context.Context, arg IsApproverGroupSupervisorParams) (bool, error)
+	IsItemPoolMember(ctx context.Context, arg IsItemPoolMemberParams) (bool, error)
+	IsItemSigner(ctx context.Context, arg IsItemSignerParams) (bool, error)
+	ListActiveRecordSignatures(ctx context.Context, recordID int64) ([]RecordSignature, error)
 	ListAdmins(ctx context.Context, arg ListAdminsParams) ([]Admin, error)
+	ListAllItemPrerequisites(ctx context.Context) ([]ClearanceItemPrerequisite, error)
 	ListAllRequests(ctx context.Context) ([]ClearanceRequest, error)
+	ListApproverDelegationsForStaff(ctx context.Context, delegatorStaffID int64) ([]ApproverDelegation, error)
+	ListApproverGroupMembers(ctx context.Context, groupID int64) ([]ApproverGroupMember, error)
+	ListApproverGroups(ctx context.Context) ([]ApproverGroup, error)
+	ListApproverQueue(ctx context.Context, arg ListApproverQueueParams) ([]ListApproverQueueRow, error)
+	ListCertificateItems(ctx context.Context, requestID sql.NullInt64) ([]ListCertificateItemsRow, error)
+	ListClearanceItemSigners(ctx context.Context, clearanceItemID int64) ([]ClearanceItemSigner, error)
 	ListClearanceItems(ctx context.Context) ([]ClearanceItem, error)
+	ListClearanceRecordEvents(ctx context.Context, recordID int64) ([]ClearanceRecordEvent, error)
+	ListClearanceTypes(ctx context.Context) ([]ClearanceType, error)
 	ListDepartments(ctx context.Context) ([]Department, error)
+	ListItemPoolMembers(ctx context.Context, id int64) ([]ListItemPoolMembersRow, error)
+	ListItemPrerequisites(ctx context.Context, itemID int64) ([]ClearanceItemPrerequisite, error)
 	ListItemsByDepartment(ctx context.Context, departmentID int64) ([]ClearanceItem, error)
 	ListNotificationsForStudent(ctx context.Context, recipientStudentID sql.NullInt64) ([]Notification, error)
 	ListNotificationsForUser(ctx context.Context, recipientUserID sql.NullInt64) ([]Notification, error)
+	ListObligations(ctx context.Context, arg ListObligationsParams) ([]StudentObligation, error)
+	ListRecordAttachments(ctx context.Context, recordID int64) ([]RecordAttachment, error)
+	ListRecordComments(ctx context.Context, recordID int64) ([]ListRecordCommentsRow, error)
+	ListRecordSignatures(ctx context.Context, recordID int64) ([]RecordSignature, error)
 	ListRecordsBySession(ctx context.Context, sessionID int64) ([]ClearanceRecord, error)
+	ListRecordsBySessionForStaff(ctx context.Context, arg ListRecordsBySessionForStaffParams) ([]ClearanceRecord, error)
 	ListRecordsByStudent(ctx context.Context, studentID int64) ([]ClearanceRecord, error)
+	ListRecordsByStudentForStaff(ctx context.Context, arg ListRecordsByStudentForStaffParams) ([]ClearanceRecord, error)
+	ListRecordsMissingAttachments(ctx context.Context, arg ListRecordsMissingAttachmentsParams) ([]ListRecordsMissingAttachmentsRow, error)
+	ListRequestRecordItems(ctx context.Context, requestID sql.NullInt64) ([]ListRequestRecordItemsRow, error)
 	ListRequestsByStudent(ctx context.Context, studentID int64) ([]ClearanceRequest, error)
 	ListRoles(ctx context.Context) ([]Role, error)
+	ListRulesByItem(ctx context.Context, clearanceItemID int64) ([]ClearanceItemRule, error)
+	ListRulesForItems(ctx context.Context, itemIds []int64) ([]ClearanceItemRule, error)
+	ListSessionClearanceItems(ctx context.Context, sessionID int64) ([]ListSessionClearanceItemsRow, error)
 	ListSessions(ctx context.Context) ([]ClearanceSession, error)
 	ListStaffUsers(ctx context.Context, arg ListStaffUsersParams) ([]StaffUser, error)
+	ListStudentObligations(ctx context.Context, studentID int64) ([]ListStudentObligationsRow, error)
+	ListStudentPayments(ctx context.Context, studentID int64) ([]Payment, error)
+	ListStudentRecordsWaitingInDepartment(ctx context.Context, arg ListStudentRecordsWaitingInDepartmentParams) ([]ClearanceRecord, error)
 	ListStudents(ctx context.Context, arg ListStudentsParams) ([]Student, error)
+	LockItemPrerequisites(ctx context.Context) error
 	MarkNotificationRead(ctx context.Context, id int64) (Notification, error)
+	ResolveStudentObligation(ctx context.Context, arg ResolveStudentObligationParams) (StudentObligation, error)
+	RevokeRequestCertificates(ctx context.Context, arg RevokeRequestCertificatesParams) ([]ClearanceCertificate, error)
+	SetClearanceItemPool(ctx context.Context, arg SetClearanceItemPoolParams) (ClearanceItem, error)
+	SetClearanceItemSignaturePolicy(ctx context.Context, arg SetClearanceItemSignaturePolicyParams) (ClearanceItem, error)
+	SetPaymentCheckout(ctx context.Context, arg SetPaymentCheckoutParams) (Payment, error)
 	UpdateAdmin(ctx context.Context, arg UpdateAdminParams) (Admin, error)
 	UpdateAdminPassword(ctx context.Context, arg UpdateAdminPasswordParams) error
 	UpdateClearanceItem(ctx context.Context, arg UpdateClearanceItemParams) (ClearanceItem, error)
 	UpdateClearanceRecordStatus(ctx context.Context, arg UpdateClearanceRecordStatusParams) (ClearanceRecord, error)
+	UpdateClearanceRequestProgress(ctx context.Context, arg UpdateClearanceRequestProgressParams) (ClearanceRequest, error)
 	UpdateClearanceRequestStatus(ctx context.Context, arg UpdateClearanceRequestStatusParams) error
+	UpdateClearanceType(ctx context.Context, arg UpdateClearanceTypeParams) (ClearanceType, error)
 	UpdateDepartment(ctx context.Context, arg UpdateDepartmentParams) (Department, error)
 	UpdateSession(ctx context.Context, arg UpdateSessionParams) (ClearanceSession, error)
 	UpdateStaffUser(ctx context.Context, arg UpdateStaffUserParams) (StaffUser, error)
 	UpdateStudent(ctx context.Context, arg UpdateStudentParams) (Student, error)
 	UpdateStudentPartial(ctx context.Context, arg UpdateStudentPartialParams) (Student, error)
+	UpsertApproverGroupMember(ctx context.Context, arg UpsertApproverGroupMemberParams) (ApproverGroupMember, error)
+	UpsertSessionItem(ctx context.Context, arg UpsertSessionItemParams) (SessionItem, error)
+	UpsertStudentCredential(ctx context.Context, arg UpsertStudentCredentialParams) (StudentCredential, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -0,0 +1,73 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Allowed values of clearance_records.status.
+const (
+	RecordStatusPending     = "pending"
+	RecordStatusInReview    = "in_review"
+	RecordStatusApproved    = "approved"
+	RecordStatusRejected    = "rejected"
+	RecordStatusResubmitted = "resubmitted"
+	RecordStatusWaived      = "waived"
+)
+
+var (
+	ErrInvalidRecordStatus     = errors.New("invalid clearance record status")
+	ErrInvalidStatusTransition = errors.New("invalid clearance record status transition")
+	// ErrResubmissionLimitReached is returned when a record has already been
+	// resubmitted the maximum number of times.
+	ErrResubmissionLimitReached = errors.New("clearance record resubmission limit reached")
+)
+
+// recordTransitions lists, for every status, the statuses a record may move
+// to without an admin override. Approved and waived are final.
+var recordTransitions = map[string][]string{
+	RecordStatusPending:     {RecordStatusInReview, RecordStatusApproved, RecordStatusRejected, RecordStatusWaived},
+	RecordStatusInReview:    {RecordStatusApproved, RecordStatusRejected, RecordStatusWaived},
+	RecordStatusRejected:    {RecordStatusResubmitted, RecordStatusWaived},
+	RecordStatusResubmitted: {RecordStatusInReview, RecordStatusApproved, RecordStatusRejected, RecordStatusWaived},
+	RecordStatusApproved:    {},
+	RecordStatusWaived:      {},
+}
+
+// IsValidRecordStatus reports whether status is one of the known record statuses.
+func IsValidRecordStatus(status string) bool {
+	_, ok := recordTransitions[status]
+	return ok
+}
+
+// IsFinalRecordStatus reports whether status is a known status a record
+// can't leave without an admin override.
+func IsFinalRecordStatus(status string) bool {
+	next, ok := recordTransitions[status]
+	return ok && len(next) == 0
+}
+
+// CanTransitionRecord reports whether a record may move from one status to another.
+func CanTransitionRecord(from, to string) bool {
+	for _, next := range recordTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateRecordTransition returns nil when the transition is allowed. With
+// override set (admins only) any move between known statuses is accepted.
+func ValidateRecordTransition(from, to string, override bool) error {
+	if !IsValidRecordStatus(to) {
+		return fmt.Errorf("%w: %q", ErrInvalidRecordStatus, to)
+	}
+	if override && IsValidRecordStatus(from) {
+		return nil
+	}
+	if !CanTransitionRecord(from, to) {
+		return fmt.Errorf("%w: cannot change status from %q to %q", ErrInvalidStatusTransition, from, to)
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package db
+
+// Allowed values of clearance_requests.status.
+const (
+	RequestStatusPending    = "pending"
+	RequestStatusInProgress = "in_progress"
+	RequestStatusBlocked    = "blocked"
+	RequestStatusCleared    = "cleared"
+)
+
+// RollUpRequestStatus derives a request's status from the statuses of its
+// records: cleared when every record is approved or waived, blocked when any
+// record is rejected and in progress otherwise.
+func RollUpRequestStatus(counts GetRecordStatusCountsRow) string {
+	switch {
+	case counts.Total > 0 && counts.Done == counts.Total:
+		return RequestStatusCleared
+	case counts.Rejected > 0:
+		return RequestStatusBlocked
+	default:
+		return RequestStatusInProgress
+	}
+}
@@ -0,0 +1,61 @@
+package db
+
+import "errors"
+
+// ErrItemNotActionable is returned when a record is approved before the
+// items it has to wait for are done.
+var ErrItemNotActionable = errors.New("clearance item is not actionable yet: the items it waits for must be approved or waived first")
+
+// isDoneStatus reports whether a record status satisfies later items.
+func isDoneStatus(status string) bool {
+	return status == RecordStatusApproved || status == RecordStatusWaived
+}
+
+// ActionableRecords returns the IDs of the records that can be decided now.
+// A record waits for the records of its prerequisite items to be approved
+// or waived; prerequisites without a record in the request don't apply to
+// the student and are skipped. Items with enforce_sequence additionally
+// wait for every lower-sequence record.
+func ActionableRecords(rows []ListRequestRecordItemsRow) map[int64]bool {
+	done := make(map[int64]bool, len(rows))
+	for _, row := range rows {
+		done[row.ItemID] = isDoneStatus(row.Status)
+	}
+
+	actionable := make(map[int64]bool, len(rows))
+	for _, row := range rows {
+		ready := true
+		for _, prerequisite := range row.PrerequisiteItemIds {
+			if isDone, ok := done[prerequisite]; ok && !isDone {
+				ready = false
+				break
+			}
+		}
+
+		if ready && row.EnforceSequence {
+			for _, other := range rows {
+				if other.Sequence < row.Sequence && !isDoneStatus(other.Status) {
+					ready = false
+					break
+				}
+			}
+		}
+		actionable[row.RecordID] = ready
+	}
+	return actionable
+}
+
+// newlyActionable returns the open rows that are actionable in after but
+// were not in before.
+func newlyActionable(before, after []ListRequestRecordItemsRow) []ListRequestRecordItemsRow {
+	was := ActionableRecords(before)
+	now := ActionableRecords(after)
+
+	var rows []ListRequestRecordItemsRow
+	for _, row := range after {
+		if now[row.RecordID] && !was[row.RecordID] && !isDoneStatus(row.Status) {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
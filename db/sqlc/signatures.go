@@ -0,0 +1,102 @@
+package db
+
+import (
+	"context"
+	"errors"
+)
+
+// Allowed values of clearance_items.rejection_policy.
+const (
+	RejectionPolicyBlock = "block"
+	RejectionPolicyReset = "reset"
+)
+
+// ErrSignatureBlocked is returned when a multi-signature item is approved
+// while a signer's rejection still stands.
+var ErrSignatureBlocked = errors.New("clearance item is blocked by a rejected signature")
+
+// ErrSignatureDecisionOnly is returned when a signer tries to move a record
+// of a multi-signature item to anything but approved or rejected. Waiving
+// such an item would skip the other signers, so it is left to admins.
+var ErrSignatureDecisionOnly = errors.New("signers can only approve or reject items that require several signatures")
+
+// CountSignatures counts the approvals and rejections among the active
+// signatures of a record.
+func CountSignatures(signatures []RecordSignature) (approvals, rejections int32) {
+	for _, s := range signatures {
+		if s.InvalidatedAt.Valid {
+			continue
+		}
+		switch s.Decision {
+		case RecordStatusApproved:
+			approvals++
+		case RecordStatusRejected:
+			rejections++
+		}
+	}
+	return approvals, rejections
+}
+
+// signClearanceRecord stores the signer's decision on a record of a
+// multi-signature item. It reports whether the decision should also be
+// applied to the record: always for rejections, and for approvals once
+// enough distinct signers agree. Records of single-signature items are
+// passed through unsigned; any other status is refused with
+// ErrSignatureDecisionOnly.
+func signClearanceRecord(ctx context.Context, q Querier, arg UpdateClearanceRecordStatusTxParams) (RecordSignature, bool, error) {
+	item, err := q.GetRecordItem(ctx, arg.RecordID)
+	if err != nil {
+		return RecordSignature{}, false, err
+	}
+	if item.RequiredSignatures <= 1 {
+		return RecordSignature{}, true, nil
+	}
+	if arg.Status != RecordStatusApproved && arg.Status != RecordStatusRejected {
+		return RecordSignature{}, false, ErrSignatureDecisionOnly
+	}
+
+	// a signer's new decision replaces their previous one
+	err = q.InvalidateStaffSignature(ctx, InvalidateStaffSignatureParams{
+		RecordID: arg.RecordID,
+		StaffID:  arg.SignerStaffID.Int64,
+	})
+	if err != nil {
+		return RecordSignature{}, false, err
+	}
+
+	signature, err := q.CreateRecordSignature(ctx, CreateRecordSignatureParams{
+		RecordID: arg.RecordID,
+		StaffID:  arg.SignerStaffID.Int64,
+		Decision: arg.Status,
+		Note:     arg.Note,
+	})
+	if err != nil {
+		return RecordSignature{}, false, err
+	}
+
+	if arg.Status == RecordStatusRejected {
+		if item.RejectionPolicy == RejectionPolicyReset {
+			invalidated, err := q.InvalidateRecordSignatures(ctx, arg.RecordID)
+			if err != nil {
+				return RecordSignature{}, false, err
+			}
+			for _, s := range invalidated {
+				if s.ID == signature.ID {
+					signature = s
+				}
+			}
+		}
+		return signature, true, nil
+	}
+
+	active, err := q.ListActiveRecordSignatures(ctx, arg.RecordID)
+	if err != nil {
+		return RecordSignature{}, false, err
+	}
+
+	approvals, rejections := CountSignatures(active)
+	if rejections > 0 {
+		return RecordSignature{}, false, ErrSignatureBlocked
+	}
+	return signature, approvals >= item.RequiredSignatures, nil
+}
@@ -9,6 +9,9 @@ import (
 type Store interface {
 	Querier
 	ExecTx(ctx context.Context, fn func(q Querier) error) error
+	SubmitClearanceRequestTx(ctx context.Context, arg SubmitClearanceRequestTxParams) (SubmitClearanceRequestTxResult, error)
+	UpdateClearanceRecordStatusTx(ctx context.Context, arg UpdateClearanceRecordStatusTxParams) (UpdateClearanceRecordStatusTxResult, error)
+	ConfirmPaymentTx(ctx context.Context, arg ConfirmPaymentTxParams) (ConfirmPaymentTxResult, error)
 }
 
 // SQLStore provides all functions to execute SQL queries and transactions.
@@ -0,0 +1,70 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	db "github.com/backendn/clearance_system/db/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDelegatedRecordsInSubstituteQueue(t *testing.T) {
+	store := db.NewStore(testDB)
+	ctx := context.Background()
+	approver, record := createRandomRequestRecord(t, false)
+	substitute := createRandomStaffUser(t)
+
+	arg := db.ListApproverQueueParams{
+		ApproverStaffID: substitute.ID,
+		Statuses:        []string{db.RecordStatusPending},
+		PageLimit:       10,
+	}
+	rows, err := testQueries.ListApproverQueue(ctx, arg)
+	require.NoError(t, err)
+	require.Empty(t, rows)
+
+	// a delegation that already ended grants nothing
+	past := time.Now().AddDate(0, 0, -10)
+	_, err = testQueries.CreateApproverDelegation(ctx, db.CreateApproverDelegationParams{
+		DelegatorStaffID:  approver.ID,
+		SubstituteStaffID: substitute.ID,
+		StartsOn:          past,
+		EndsOn:            past.AddDate(0, 0, 2),
+	})
+	require.NoError(t, err)
+
+	active, err := testQueries.HasActiveDelegation(ctx, db.HasActiveDelegationParams{
+		DelegatorStaffID:  approver.ID,
+		SubstituteStaffID: substitute.ID,
+	})
+	require.NoError(t, err)
+	require.False(t, active)
+
+	_, err = testQueries.CreateApproverDelegation(ctx, db.CreateApproverDelegationParams{
+		DelegatorStaffID:  approver.ID,
+		SubstituteStaffID: substitute.ID,
+		StartsOn:          time.Now().AddDate(0, 0, -1),
+		EndsOn:            time.Now().AddDate(0, 0, 1),
+		Reason:            "annual leave",
+	})
+	require.NoError(t, err)
+
+	rows, err = testQueries.ListApproverQueue(ctx, arg)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	require.Equal(t, record.ID, rows[0].RecordID)
+	require.Equal(t, approver.ID, rows[0].ResponsibleStaffID.Int64)
+
+	result, err := store.UpdateClearanceRecordStatusTx(ctx, db.UpdateClearanceRecordStatusTxParams{
+		RecordID:   record.ID,
+		Status:     db.RecordStatusApproved,
+		HandledBy:  sql.NullInt64{Int64: substitute.ID, Valid: true},
+		ActorRole:  "staff",
+		ActorID:    substitute.ID,
+		OnBehalfOf: sql.NullInt64{Int64: approver.ID, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, approver.ID, result.Event.OnBehalfOfStaffID.Int64)
+}
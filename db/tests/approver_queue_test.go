@@ -0,0 +1,43 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	db "github.com/backendn/clearance_system/db/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListApproverQueue(t *testing.T) {
+	ctx := context.Background()
+	staff, first := createRandomRequestRecord(t, false)
+
+	arg := db.ListApproverQueueParams{
+		ApproverStaffID: staff.ID,
+		Statuses:        []string{db.RecordStatusPending, db.RecordStatusResubmitted},
+		PageLimit:       10,
+	}
+
+	rows, err := testQueries.ListApproverQueue(ctx, arg)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	require.Equal(t, first.ID, rows[0].RecordID)
+	require.Equal(t, first.StudentID, rows[0].StudentID)
+
+	// a cursor past the only row yields an empty page
+	arg.CursorUpdatedAt.Time, arg.CursorUpdatedAt.Valid = rows[0].WaitingSince, true
+	arg.CursorID.Int64, arg.CursorID.Valid = rows[0].RecordID, true
+	rows, err = testQueries.ListApproverQueue(ctx, arg)
+	require.NoError(t, err)
+	require.Empty(t, rows)
+
+	// other approvers don't see the record
+	arg = db.ListApproverQueueParams{
+		ApproverStaffID: createRandomStaffUser(t).ID,
+		Statuses:        []string{db.RecordStatusPending},
+		PageLimit:       10,
+	}
+	rows, err = testQueries.ListApproverQueue(ctx, arg)
+	require.NoError(t, err)
+	require.Empty(t, rows)
+}
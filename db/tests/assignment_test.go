@@ -0,0 +1,39 @@
+package tests
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	db "github.com/backendn/clearance_system/db/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPickAssignee(t *testing.T) {
+	now := time.Now()
+	members := []db.ListItemPoolMembersRow{
+		{StaffID: 1, OpenRecords: 3, LastAssignedAt: sql.NullTime{Time: now.Add(-time.Hour), Valid: true}},
+		{StaffID: 2, OpenRecords: 1, LastAssignedAt: sql.NullTime{Time: now, Valid: true}},
+		{StaffID: 3, OpenRecords: 5},
+	}
+
+	// never assigned comes first, then the longest wait
+	staffID, ok := db.PickAssignee(db.AssignmentRoundRobin, members)
+	require.True(t, ok)
+	require.Equal(t, int64(3), staffID)
+
+	staffID, ok = db.PickAssignee(db.AssignmentRoundRobin, members[:2])
+	require.True(t, ok)
+	require.Equal(t, int64(1), staffID)
+
+	staffID, ok = db.PickAssignee(db.AssignmentLeastLoaded, members)
+	require.True(t, ok)
+	require.Equal(t, int64(2), staffID)
+
+	// claim leaves the record for the pool
+	_, ok = db.PickAssignee(db.AssignmentClaim, members)
+	require.False(t, ok)
+
+	_, ok = db.PickAssignee(db.AssignmentLeastLoaded, nil)
+	require.False(t, ok)
+}
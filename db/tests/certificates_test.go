@@ -0,0 +1,110 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	db "github.com/backendn/clearance_system/db/sqlc"
+	"github.com/backendn/clearance_system/util"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateClearanceCertificate(t *testing.T) {
+	ctx := context.Background()
+	_, record := createRandomRequestRecord(t, false)
+	requestID := record.RequestID.Int64
+
+	arg := db.CreateClearanceCertificateParams{
+		RequestID:         requestID,
+		StudentID:         record.StudentID,
+		CertificateNumber: "CLR-TEST-" + util.RandomString(8),
+		StorageKey:        "certificates/" + util.RandomString(8) + ".pdf",
+		SizeBytes:         1024,
+		ChecksumSha256:    util.RandomString(64),
+		Payload:           `{"number":"test"}`,
+		Signature:         util.RandomString(88),
+		KeyID:             util.RandomString(16),
+		IssuedAt:          time.Now().UTC().Truncate(time.Second),
+		VerificationCode:  util.RandomString(16),
+	}
+	cert, err := testQueries.CreateClearanceCertificate(ctx, arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.CertificateNumber, cert.CertificateNumber)
+
+	got, err := testQueries.GetCertificateByRequest(ctx, requestID)
+	require.NoError(t, err)
+	require.Equal(t, cert.ID, got.ID)
+
+	// a request only ever gets one certificate
+	arg.CertificateNumber += "-2"
+	arg.VerificationCode = util.RandomString(16)
+	_, err = testQueries.CreateClearanceCertificate(ctx, arg)
+	var pqErr *pq.Error
+	require.ErrorAs(t, err, &pqErr)
+	require.Equal(t, pq.ErrorCode("23505"), pqErr.Code)
+
+	items, err := testQueries.ListCertificateItems(ctx, sql.NullInt64{Int64: requestID, Valid: true})
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	require.Equal(t, record.ID, items[0].RecordID)
+}
+
+func TestReopeningRequestRevokesCertificate(t *testing.T) {
+	store := db.NewStore(testDB)
+	ctx := context.Background()
+	staff, record := createRandomRequestRecord(t, false)
+
+	approved, err := store.UpdateClearanceRecordStatusTx(ctx, db.UpdateClearanceRecordStatusTxParams{
+		RecordID:  record.ID,
+		Status:    db.RecordStatusApproved,
+		HandledBy: sql.NullInt64{Int64: staff.ID, Valid: true},
+		ActorRole: "staff",
+		ActorID:   staff.ID,
+	})
+	require.NoError(t, err)
+	require.True(t, approved.Cleared)
+
+	cert, err := testQueries.CreateClearanceCertificate(ctx, db.CreateClearanceCertificateParams{
+		RequestID:         approved.Request.ID,
+		StudentID:         record.StudentID,
+		CertificateNumber: "CLR-TEST-" + util.RandomString(8),
+		StorageKey:        "certificates/" + util.RandomString(8) + ".pdf",
+		SizeBytes:         1024,
+		ChecksumSha256:    util.RandomString(64),
+		Payload:           `{"number":"test"}`,
+		Signature:         util.RandomString(88),
+		KeyID:             util.RandomString(16),
+		IssuedAt:          time.Now().UTC().Truncate(time.Second),
+		VerificationCode:  util.RandomString(16),
+	})
+	require.NoError(t, err)
+
+	reopened, err := store.UpdateClearanceRecordStatusTx(ctx, db.UpdateClearanceRecordStatusTxParams{
+		RecordID:  record.ID,
+		Status:    db.RecordStatusRejected,
+		Note:      "approved by mistake",
+		ActorRole: "admin",
+		ActorID:   1,
+		Override:  true,
+	})
+	require.NoError(t, err)
+	require.False(t, reopened.Cleared)
+	require.Len(t, reopened.RevokedCertificates, 1)
+	require.Equal(t, cert.ID, reopened.RevokedCertificates[0].ID)
+
+	// revoked certificates can still be verified but are no longer active
+	got, err := testQueries.GetCertificateByVerificationCode(ctx, cert.VerificationCode)
+	require.NoError(t, err)
+	require.True(t, got.RevokedAt.Valid)
+	require.NotEmpty(t, got.RevocationReason)
+
+	_, err = testQueries.GetCertificateByRequest(ctx, approved.Request.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	count, err := testQueries.CountRequestCertificates(ctx, approved.Request.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+}
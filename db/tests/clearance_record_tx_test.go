@@ -0,0 +1,239 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	db "github.com/backendn/clearance_system/db/sqlc"
+	"github.com/backendn/clearance_system/util"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+// helper: submit a request whose type has a single item approved by a new
+// staff user, and return that staff user and the item's record
+func createRandomRequestRecord(t *testing.T, requiresAttachment bool) (db.StaffUser, db.ClearanceRecord) {
+	store := db.NewStore(testDB)
+	ctx := context.Background()
+
+	staff := createRandomStaffUser(t)
+	clearanceType := createRandomClearanceType(t)
+	item, err := testQueries.CreateClearanceItem(ctx, db.CreateClearanceItemParams{
+		Code:               util.RandomString(12),
+		Title:              util.RandomString(10),
+		DepartmentID:       staff.DepartmentID,
+		ApproverStaffID:    staff.ID,
+		RequiresAttachment: requiresAttachment,
+		Sequence:           1,
+		ClearanceTypeID:    sql.NullInt64{Int64: clearanceType.ID, Valid: true},
+	})
+	require.NoError(t, err)
+
+	submitted, err := store.SubmitClearanceRequestTx(ctx, db.SubmitClearanceRequestTxParams{
+		StudentID:       createRandomStudent(t).ID,
+		SessionID:       createRandomSession(t).ID,
+		ClearanceTypeID: clearanceType.ID,
+	})
+	require.NoError(t, err)
+
+	var record db.ClearanceRecord
+	for _, r := range submitted.Records {
+		if r.ClearanceItemID == item.ID {
+			record = r
+		}
+	}
+	require.NotZero(t, record.ID)
+
+	return staff, record
+}
+
+func TestUpdateClearanceRecordStatusTxRequiresAttachment(t *testing.T) {
+	store := db.NewStore(testDB)
+	ctx := context.Background()
+	staff, record := createRandomRequestRecord(t, true)
+
+	approve := db.UpdateClearanceRecordStatusTxParams{
+		RecordID:  record.ID,
+		Status:    db.RecordStatusApproved,
+		HandledBy: sql.NullInt64{Int64: staff.ID, Valid: true},
+		ActorRole: "staff",
+		ActorID:   staff.ID,
+	}
+
+	// neither the student nor the approver can move on without a file
+	_, err := store.UpdateClearanceRecordStatusTx(ctx, db.UpdateClearanceRecordStatusTxParams{
+		RecordID:  record.ID,
+		Status:    db.RecordStatusInReview,
+		ActorRole: "student",
+		ActorID:   record.StudentID,
+	})
+	require.ErrorIs(t, err, db.ErrAttachmentRequired)
+
+	_, err = store.UpdateClearanceRecordStatusTx(ctx, approve)
+	require.ErrorIs(t, err, db.ErrAttachmentRequired)
+
+	missing, err := testQueries.ListRecordsMissingAttachments(ctx, db.ListRecordsMissingAttachmentsParams{
+		ApproverStaffID: sql.NullInt64{Int64: staff.ID, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Len(t, missing, 1)
+	require.Equal(t, record.ID, missing[0].RecordID)
+
+	// a file sent with a comment is not the record's own
+	_, err = testQueries.CreateRecordAttachment(ctx, db.CreateRecordAttachmentParams{
+		RecordID:       record.ID,
+		StorageKey:     "records/test/" + util.RandomString(16),
+		FileName:       "question.pdf",
+		ContentType:    "application/pdf",
+		SizeBytes:      1024,
+		ChecksumSha256: util.RandomString(64),
+		UploadedByRole: "student",
+		UploadedByID:   record.StudentID,
+		Source:         db.AttachmentSourceComment,
+	})
+	require.NoError(t, err)
+
+	_, err = store.UpdateClearanceRecordStatusTx(ctx, approve)
+	require.ErrorIs(t, err, db.ErrAttachmentRequired)
+
+	missing, err = testQueries.ListRecordsMissingAttachments(ctx, db.ListRecordsMissingAttachmentsParams{
+		ApproverStaffID: sql.NullInt64{Int64: staff.ID, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Len(t, missing, 1)
+
+	_, err = testQueries.CreateRecordAttachment(ctx, db.CreateRecordAttachmentParams{
+		RecordID:       record.ID,
+		StorageKey:     "records/test/" + util.RandomString(16),
+		FileName:       "receipt.pdf",
+		ContentType:    "application/pdf",
+		SizeBytes:      1024,
+		ChecksumSha256: util.RandomString(64),
+		UploadedByRole: "student",
+		UploadedByID:   record.StudentID,
+		Source:         db.AttachmentSourceRecord,
+	})
+	require.NoError(t, err)
+
+	result, err := store.UpdateClearanceRecordStatusTx(ctx, approve)
+	require.NoError(t, err)
+	require.Equal(t, db.RecordStatusApproved, result.Record.Status)
+
+	// failed attempts leave no trace; the history holds creation and approval
+	events, err := testQueries.ListClearanceRecordEvents(ctx, record.ID)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.False(t, events[0].OldStatus.Valid)
+	require.Equal(t, db.RecordStatusPending, events[0].NewStatus)
+	require.Equal(t, db.RecordStatusPending, events[1].OldStatus.String)
+	require.Equal(t, db.RecordStatusApproved, events[1].NewStatus)
+	require.Equal(t, staff.ID, events[1].ActorID)
+}
+
+func TestUpdateClearanceRecordStatusTxResubmissionLimit(t *testing.T) {
+	store := db.NewStore(testDB)
+	ctx := context.Background()
+	staff, record := createRandomRequestRecord(t, false)
+
+	reject := db.UpdateClearanceRecordStatusTxParams{
+		RecordID:  record.ID,
+		Status:    db.RecordStatusRejected,
+		HandledBy: sql.NullInt64{Int64: staff.ID, Valid: true},
+		ActorRole: "staff",
+		ActorID:   staff.ID,
+	}
+	resubmit := db.UpdateClearanceRecordStatusTxParams{
+		RecordID:         record.ID,
+		Status:           db.RecordStatusResubmitted,
+		Note:             util.RandomString(20),
+		ActorRole:        "student",
+		ActorID:          record.StudentID,
+		MaxResubmissions: 1,
+	}
+
+	_, err := store.UpdateClearanceRecordStatusTx(ctx, reject)
+	require.NoError(t, err)
+
+	result, err := store.UpdateClearanceRecordStatusTx(ctx, resubmit)
+	require.NoError(t, err)
+	require.Equal(t, db.RecordStatusResubmitted, result.Record.Status)
+	// the student's update keeps the staff member as the last handler
+	require.Equal(t, staff.ID, result.Record.HandledBy.Int64)
+
+	_, err = store.UpdateClearanceRecordStatusTx(ctx, reject)
+	require.NoError(t, err)
+
+	_, err = store.UpdateClearanceRecordStatusTx(ctx, resubmit)
+	require.ErrorIs(t, err, db.ErrResubmissionLimitReached)
+}
+
+func TestClearanceRecordHistoryCannotBeErased(t *testing.T) {
+	ctx := context.Background()
+	_, record := createRandomRequestRecord(t, false)
+
+	events, err := testQueries.ListClearanceRecordEvents(ctx, record.ID)
+	require.NoError(t, err)
+	require.NotEmpty(t, events)
+
+	// the record keeps its history, so it cannot be deleted
+	_, err = testDB.ExecContext(ctx, "DELETE FROM clearance_records WHERE id = $1", record.ID)
+	var pqErr *pq.Error
+	require.ErrorAs(t, err, &pqErr)
+	require.Equal(t, pq.ErrorCode("23503"), pqErr.Code)
+
+	_, err = testDB.ExecContext(ctx, "DELETE FROM clearance_record_events WHERE record_id = $1", record.ID)
+	require.Error(t, err)
+}
+
+func TestUpdateClearanceRecordStatusTxWaiveFollowsSequence(t *testing.T) {
+	store := db.NewStore(testDB)
+	ctx := context.Background()
+
+	staff := createRandomStaffUser(t)
+	clearanceType := createRandomClearanceType(t)
+	var items []db.ClearanceItem
+	for sequence := int32(1); sequence <= 2; sequence++ {
+		item, err := testQueries.CreateClearanceItem(ctx, db.CreateClearanceItemParams{
+			Code:            util.RandomString(12),
+			Title:           util.RandomString(10),
+			DepartmentID:    staff.DepartmentID,
+			ApproverStaffID: staff.ID,
+			Sequence:        sequence,
+			EnforceSequence: true,
+			ClearanceTypeID: sql.NullInt64{Int64: clearanceType.ID, Valid: true},
+		})
+		require.NoError(t, err)
+		items = append(items, item)
+	}
+
+	submitted, err := store.SubmitClearanceRequestTx(ctx, db.SubmitClearanceRequestTxParams{
+		StudentID:       createRandomStudent(t).ID,
+		SessionID:       createRandomSession(t).ID,
+		ClearanceTypeID: clearanceType.ID,
+	})
+	require.NoError(t, err)
+
+	var second db.ClearanceRecord
+	for _, r := range submitted.Records {
+		if r.ClearanceItemID == items[1].ID {
+			second = r
+		}
+	}
+	require.NotZero(t, second.ID)
+
+	// waiving the second item must wait for the first, like approving it
+	waive := db.UpdateClearanceRecordStatusTxParams{
+		RecordID:  second.ID,
+		Status:    db.RecordStatusWaived,
+		HandledBy: sql.NullInt64{Int64: staff.ID, Valid: true},
+		ActorRole: "staff",
+		ActorID:   staff.ID,
+	}
+	_, err = store.UpdateClearanceRecordStatusTx(ctx, waive)
+	require.ErrorIs(t, err, db.ErrItemNotActionable)
+
+	waive.Override = true
+	_, err = store.UpdateClearanceRecordStatusTx(ctx, waive)
+	require.NoError(t, err)
+}
@@ -0,0 +1,100 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	db "github.com/backendn/clearance_system/db/sqlc"
+	"github.com/backendn/clearance_system/util"
+	"github.com/stretchr/testify/require"
+)
+
+// helper: create random staff user in its own department
+func createRandomStaffUser(t *testing.T) db.StaffUser {
+	dept := createRandomDepartment(t)
+
+	role, err := testQueries.CreateRole(context.Background(), util.RandomString(10))
+	require.NoError(t, err)
+
+	staff, err := testQueries.CreateStaffUser(context.Background(), db.CreateStaffUserParams{
+		Username:     util.RandomString(10),
+		Email:        util.RandomEmail(),
+		FullName:     util.RandomString(12),
+		DepartmentID: dept.ID,
+		RoleID:       role.ID,
+		PasswordHash: util.RandomString(20),
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, staff)
+
+	return staff
+}
+
+// helper: create random clearance item approved by a new staff user
+func createRandomClearanceItem(t *testing.T) db.ClearanceItem {
+	staff := createRandomStaffUser(t)
+
+	item, err := testQueries.CreateClearanceItem(context.Background(), db.CreateClearanceItemParams{
+		Code:            util.RandomString(12),
+		Title:           util.RandomString(10),
+		Description:     util.RandomString(20),
+		DepartmentID:    staff.DepartmentID,
+		ApproverStaffID: staff.ID,
+		Sequence:        util.RandomInt(1, 100),
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, item)
+
+	return item
+}
+
+// helper: create random clearance type
+func createRandomClearanceType(t *testing.T) db.ClearanceType {
+	clearanceType, err := testQueries.CreateClearanceType(context.Background(), db.CreateClearanceTypeParams{
+		Code:        util.RandomString(12),
+		Name:        util.RandomString(10),
+		Description: util.RandomString(20),
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, clearanceType)
+
+	return clearanceType
+}
+
+func TestSubmitClearanceRequestTx(t *testing.T) {
+	store := db.NewStore(testDB)
+	student := createRandomStudent(t)
+	session := createRandomSession(t)
+	clearanceType := createRandomClearanceType(t)
+	createRandomClearanceItem(t)
+
+	arg := db.SubmitClearanceRequestTxParams{
+		StudentID:       student.ID,
+		SessionID:       session.ID,
+		ClearanceTypeID: clearanceType.ID,
+	}
+
+	result, err := store.SubmitClearanceRequestTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, student.ID, result.Request.StudentID)
+	require.Equal(t, session.ID, result.Request.SessionID)
+	require.Equal(t, clearanceType.ID, result.Request.ClearanceTypeID)
+	require.Len(t, result.Records, len(result.Items))
+
+	for _, record := range result.Records {
+		require.Equal(t, "pending", record.Status)
+		require.Equal(t, session.ID, record.SessionID)
+		require.False(t, record.HandledBy.Valid)
+		require.Equal(t, result.Request.ID, record.RequestID.Int64)
+	}
+
+	// a second submission of the same type for the session is rejected
+	_, err = store.SubmitClearanceRequestTx(context.Background(), arg)
+	require.ErrorIs(t, err, db.ErrDuplicateClearanceRequest)
+
+	// a different type in the same session is accepted
+	arg.ClearanceTypeID = createRandomClearanceType(t).ID
+	other, err := store.SubmitClearanceRequestTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEqual(t, result.Request.ID, other.Request.ID)
+}
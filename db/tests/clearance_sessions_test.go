@@ -0,0 +1,72 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	db "github.com/backendn/clearance_system/db/sqlc"
+	"github.com/backendn/clearance_system/util"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+// helper: create random inactive session
+func createRandomSession(t *testing.T) db.ClearanceSession {
+	start := time.Now().Truncate(24 * time.Hour)
+
+	arg := db.CreateSessionParams{
+		Name:      util.RandomString(8),
+		StartDate: start,
+		EndDate:   start.AddDate(0, 1, 0),
+		Active:    false,
+	}
+
+	session, err := testQueries.CreateSession(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, session)
+	require.False(t, session.Active)
+
+	return session
+}
+
+func TestCreateSessionRejectsInvertedDates(t *testing.T) {
+	start := time.Now().Truncate(24 * time.Hour)
+
+	_, err := testQueries.CreateSession(context.Background(), db.CreateSessionParams{
+		Name:      util.RandomString(8),
+		StartDate: start,
+		EndDate:   start.AddDate(0, 0, -1),
+	})
+	require.Error(t, err)
+}
+
+func TestDeleteSessionInUse(t *testing.T) {
+	_, record := createRandomRequestRecord(t, false)
+
+	// sessions with requests or records are kept by their foreign keys
+	err := testQueries.DeleteSession(context.Background(), record.SessionID)
+	var pqErr *pq.Error
+	require.ErrorAs(t, err, &pqErr)
+	require.Equal(t, pq.ErrorCode("23503"), pqErr.Code)
+}
+
+func TestActivateSessionIsExclusive(t *testing.T) {
+	session1 := createRandomSession(t)
+	session2 := createRandomSession(t)
+
+	store := db.NewStore(testDB)
+	for _, s := range []db.ClearanceSession{session1, session2} {
+		err := store.ExecTx(context.Background(), func(q db.Querier) error {
+			if err := q.DeactivateAllSessions(context.Background()); err != nil {
+				return err
+			}
+			return q.ActivateSession(context.Background(), s.ID)
+		})
+		require.NoError(t, err)
+	}
+
+	active, err := testQueries.GetActiveSession(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, session2.ID, active.ID)
+}
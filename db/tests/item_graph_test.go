@@ -0,0 +1,61 @@
+package tests
+
+import (
+	"database/sql"
+	"testing"
+
+	db "github.com/backendn/clearance_system/db/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindPrerequisiteCycle(t *testing.T) {
+	// library and lab run in parallel, finance follows both
+	links := []db.ClearanceItemPrerequisite{
+		{ItemID: 3, PrerequisiteItemID: 1},
+		{ItemID: 3, PrerequisiteItemID: 2},
+	}
+	require.Nil(t, db.FindPrerequisiteCycle(links))
+
+	links = append(links, db.ClearanceItemPrerequisite{ItemID: 1, PrerequisiteItemID: 3})
+	require.Equal(t, []int64{1, 3, 1}, db.FindPrerequisiteCycle(links))
+}
+
+func TestValidateItemGraph(t *testing.T) {
+	items := []db.ClearanceItem{
+		{ID: 1},
+		{ID: 2, ClearanceTypeID: sql.NullInt64{Int64: 1, Valid: true}},
+		{ID: 3, ClearanceTypeID: sql.NullInt64{Int64: 2, Valid: true}},
+	}
+
+	err := db.ValidateItemGraph(items, []db.ClearanceItemPrerequisite{{ItemID: 2, PrerequisiteItemID: 1}})
+	require.NoError(t, err)
+
+	err = db.ValidateItemGraph(items, []db.ClearanceItemPrerequisite{{ItemID: 3, PrerequisiteItemID: 2}})
+	require.ErrorIs(t, err, db.ErrPrerequisiteTypeMismatch)
+
+	err = db.ValidateItemGraph(items, []db.ClearanceItemPrerequisite{
+		{ItemID: 1, PrerequisiteItemID: 2},
+		{ItemID: 2, PrerequisiteItemID: 1},
+	})
+	require.ErrorIs(t, err, db.ErrPrerequisiteCycle)
+}
+
+func TestActionableRecordsWithPrerequisites(t *testing.T) {
+	rows := []db.ListRequestRecordItemsRow{
+		{RecordID: 1, ItemID: 10, Status: db.RecordStatusPending},
+		{RecordID: 2, ItemID: 20, Status: db.RecordStatusPending},
+		// item 40 has no record in this request and is skipped
+		{RecordID: 3, ItemID: 30, Status: db.RecordStatusPending, PrerequisiteItemIds: []int64{10, 20, 40}},
+	}
+
+	actionable := db.ActionableRecords(rows)
+	require.True(t, actionable[1])
+	require.True(t, actionable[2])
+	require.False(t, actionable[3])
+
+	rows[0].Status = db.RecordStatusApproved
+	require.False(t, db.ActionableRecords(rows)[3])
+
+	rows[1].Status = db.RecordStatusWaived
+	require.True(t, db.ActionableRecords(rows)[3])
+}
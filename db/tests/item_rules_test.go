@@ -0,0 +1,49 @@
+package tests
+
+import (
+	"database/sql"
+	"testing"
+
+	db "github.com/backendn/clearance_system/db/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestItemAppliesToStudent(t *testing.T) {
+	student := db.Student{ID: 7, DepartmentID: 3, EnrollmentYear: 2021}
+
+	department := func(id int64) db.ClearanceItemRule {
+		return db.ClearanceItemRule{RuleType: db.RuleTypeDepartment, DepartmentID: sql.NullInt64{Int64: id, Valid: true}}
+	}
+	years := func(min, max int32) db.ClearanceItemRule {
+		return db.ClearanceItemRule{
+			RuleType:          db.RuleTypeEnrollmentYear,
+			MinEnrollmentYear: sql.NullInt32{Int32: min, Valid: min != 0},
+			MaxEnrollmentYear: sql.NullInt32{Int32: max, Valid: max != 0},
+		}
+	}
+	student7 := func(ruleType string) db.ClearanceItemRule {
+		return db.ClearanceItemRule{RuleType: ruleType, StudentID: sql.NullInt64{Int64: 7, Valid: true}}
+	}
+
+	testCases := []struct {
+		name    string
+		rules   []db.ClearanceItemRule
+		applies bool
+	}{
+		{"no rules", nil, true},
+		{"matching department", []db.ClearanceItemRule{department(1), department(3)}, true},
+		{"other department", []db.ClearanceItemRule{department(1)}, false},
+		{"year in range", []db.ClearanceItemRule{years(2020, 2022)}, true},
+		{"year open ended", []db.ClearanceItemRule{years(2022, 0)}, false},
+		{"department and year must both match", []db.ClearanceItemRule{department(3), years(2022, 0)}, false},
+		{"include beats attributes", []db.ClearanceItemRule{department(1), student7(db.RuleTypeIncludeStudent)}, true},
+		{"exclude beats everything", []db.ClearanceItemRule{department(3), student7(db.RuleTypeExcludeStudent)}, false},
+		{"include list only", []db.ClearanceItemRule{{RuleType: db.RuleTypeIncludeStudent, StudentID: sql.NullInt64{Int64: 8, Valid: true}}}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.applies, db.ItemAppliesToStudent(tc.rules, student))
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	db "github.com/backendn/clearance_system/db/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenObligationBlocksApproval(t *testing.T) {
+	store := db.NewStore(testDB)
+	ctx := context.Background()
+	staff, record := createRandomRequestRecord(t, false)
+
+	obligation, err := testQueries.CreateStudentObligation(ctx, db.CreateStudentObligationParams{
+		StudentID:      record.StudentID,
+		DepartmentID:   staff.DepartmentID,
+		ObligationType: db.ObligationTypeBook,
+		Description:    "unreturned library book",
+		Currency:       "USD",
+		RaisedByRole:   "staff",
+		RaisedByID:     staff.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, db.ObligationStatusOpen, obligation.Status)
+
+	approve := db.UpdateClearanceRecordStatusTxParams{
+		RecordID:  record.ID,
+		Status:    db.RecordStatusApproved,
+		HandledBy: sql.NullInt64{Int64: staff.ID, Valid: true},
+		ActorRole: "staff",
+		ActorID:   staff.ID,
+	}
+	_, err = store.UpdateClearanceRecordStatusTx(ctx, approve)
+	require.ErrorIs(t, err, db.ErrOpenObligations)
+
+	// waiving the record is no way round the obligation
+	waive := approve
+	waive.Status = db.RecordStatusWaived
+	_, err = store.UpdateClearanceRecordStatusTx(ctx, waive)
+	require.ErrorIs(t, err, db.ErrOpenObligations)
+
+	resolved, err := testQueries.ResolveStudentObligation(ctx, db.ResolveStudentObligationParams{
+		ID:             obligation.ID,
+		Status:         db.ObligationStatusResolved,
+		ResolvedByRole: sql.NullString{String: "staff", Valid: true},
+		ResolvedByID:   sql.NullInt64{Int64: staff.ID, Valid: true},
+	})
+	require.NoError(t, err)
+	require.True(t, resolved.ResolvedAt.Valid)
+
+	// a closed obligation can't be resolved again
+	_, err = testQueries.ResolveStudentObligation(ctx, db.ResolveStudentObligationParams{
+		ID:     obligation.ID,
+		Status: db.ObligationStatusWaived,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	result, err := store.UpdateClearanceRecordStatusTx(ctx, approve)
+	require.NoError(t, err)
+	require.Equal(t, db.RecordStatusApproved, result.Record.Status)
+}
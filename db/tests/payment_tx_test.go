@@ -0,0 +1,77 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	db "github.com/backendn/clearance_system/db/sqlc"
+	"github.com/backendn/clearance_system/util"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfirmPaymentTx(t *testing.T) {
+	store := db.NewStore(testDB)
+	ctx := context.Background()
+	staff, record := createRandomRequestRecord(t, false)
+
+	obligation, err := testQueries.CreateStudentObligation(ctx, db.CreateStudentObligationParams{
+		StudentID:      record.StudentID,
+		DepartmentID:   staff.DepartmentID,
+		ObligationType: db.ObligationTypeFee,
+		Amount:         2500,
+		Currency:       "USD",
+		RaisedByRole:   "staff",
+		RaisedByID:     staff.ID,
+	})
+	require.NoError(t, err)
+
+	payment, err := testQueries.CreatePayment(ctx, db.CreatePaymentParams{
+		ObligationID: obligation.ID,
+		StudentID:    obligation.StudentID,
+		Reference:    util.RandomString(20),
+		Provider:     "fake",
+		Amount:       obligation.Amount,
+		Currency:     obligation.Currency,
+	})
+	require.NoError(t, err)
+
+	// only one payment of an obligation may be open at a time
+	_, err = testQueries.CreatePayment(ctx, db.CreatePaymentParams{
+		ObligationID: obligation.ID,
+		StudentID:    obligation.StudentID,
+		Reference:    util.RandomString(20),
+		Provider:     "fake",
+		Amount:       obligation.Amount,
+		Currency:     obligation.Currency,
+	})
+	var pqErr *pq.Error
+	require.ErrorAs(t, err, &pqErr)
+	require.Equal(t, pq.ErrorCode("23505"), pqErr.Code)
+
+	active, err := testQueries.GetActiveObligationPayment(ctx, obligation.ID)
+	require.NoError(t, err)
+	require.Equal(t, payment.ID, active.ID)
+
+	confirm := db.ConfirmPaymentTxParams{
+		Reference:         payment.Reference,
+		ProviderPaymentID: "fake_1",
+		Succeeded:         true,
+		Amount:            2500,
+		Currency:          "USD",
+	}
+	result, err := store.ConfirmPaymentTx(ctx, confirm)
+	require.NoError(t, err)
+	require.False(t, result.Duplicate)
+	require.Equal(t, db.PaymentStatusSucceeded, result.Payment.Status)
+	require.Equal(t, db.ObligationStatusResolved, result.Obligation.Status)
+	require.Len(t, result.Advanced, 1)
+	require.Equal(t, record.ID, result.Advanced[0].Record.ID)
+	require.Equal(t, db.RecordStatusInReview, result.Advanced[0].Record.Status)
+
+	// providers may deliver the same callback again
+	result, err = store.ConfirmPaymentTx(ctx, confirm)
+	require.NoError(t, err)
+	require.True(t, result.Duplicate)
+	require.Empty(t, result.Advanced)
+}
@@ -0,0 +1,59 @@
+package tests
+
+import (
+	"testing"
+
+	db "github.com/backendn/clearance_system/db/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateRecordTransition(t *testing.T) {
+	testCases := []struct {
+		name     string
+		from     string
+		to       string
+		override bool
+		err      error
+	}{
+		{"pending to approved", db.RecordStatusPending, db.RecordStatusApproved, false, nil},
+		{"pending to in_review", db.RecordStatusPending, db.RecordStatusInReview, false, nil},
+		{"rejected to resubmitted", db.RecordStatusRejected, db.RecordStatusResubmitted, false, nil},
+		{"resubmitted to approved", db.RecordStatusResubmitted, db.RecordStatusApproved, false, nil},
+		{"approved to pending", db.RecordStatusApproved, db.RecordStatusPending, false, db.ErrInvalidStatusTransition},
+		{"waived to rejected", db.RecordStatusWaived, db.RecordStatusRejected, false, db.ErrInvalidStatusTransition},
+		{"rejected to approved", db.RecordStatusRejected, db.RecordStatusApproved, false, db.ErrInvalidStatusTransition},
+		{"approved to pending with override", db.RecordStatusApproved, db.RecordStatusPending, true, nil},
+		{"unknown status", db.RecordStatusPending, "done", false, db.ErrInvalidRecordStatus},
+		{"unknown status with override", db.RecordStatusPending, "done", true, db.ErrInvalidRecordStatus},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := db.ValidateRecordTransition(tc.from, tc.to, tc.override)
+			if tc.err == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestRollUpRequestStatus(t *testing.T) {
+	testCases := []struct {
+		name   string
+		counts db.GetRecordStatusCountsRow
+		status string
+	}{
+		{"all done", db.GetRecordStatusCountsRow{Total: 3, Done: 3}, db.RequestStatusCleared},
+		{"one rejected", db.GetRecordStatusCountsRow{Total: 3, Done: 1, Rejected: 1}, db.RequestStatusBlocked},
+		{"some open", db.GetRecordStatusCountsRow{Total: 3, Done: 2}, db.RequestStatusInProgress},
+		{"no records", db.GetRecordStatusCountsRow{}, db.RequestStatusInProgress},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.status, db.RollUpRequestStatus(tc.counts))
+		})
+	}
+}
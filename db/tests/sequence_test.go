@@ -0,0 +1,27 @@
+package tests
+
+import (
+	"testing"
+
+	db "github.com/backendn/clearance_system/db/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActionableRecords(t *testing.T) {
+	rows := []db.ListRequestRecordItemsRow{
+		{RecordID: 1, Status: db.RecordStatusApproved, Sequence: 1, EnforceSequence: true},
+		{RecordID: 2, Status: db.RecordStatusPending, Sequence: 2, EnforceSequence: true},
+		{RecordID: 3, Status: db.RecordStatusPending, Sequence: 3, EnforceSequence: true},
+		{RecordID: 4, Status: db.RecordStatusPending, Sequence: 4, EnforceSequence: false},
+	}
+
+	actionable := db.ActionableRecords(rows)
+	require.True(t, actionable[1])
+	require.True(t, actionable[2])
+	require.False(t, actionable[3])
+	require.True(t, actionable[4])
+
+	rows[1].Status = db.RecordStatusWaived
+	actionable = db.ActionableRecords(rows)
+	require.True(t, actionable[3])
+}
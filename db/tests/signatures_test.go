@@ -0,0 +1,84 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	db "github.com/backendn/clearance_system/db/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountSignatures(t *testing.T) {
+	signatures := []db.RecordSignature{
+		{StaffID: 1, Decision: db.RecordStatusApproved},
+		{StaffID: 2, Decision: db.RecordStatusRejected},
+		{StaffID: 3, Decision: db.RecordStatusApproved, InvalidatedAt: sql.NullTime{Time: time.Now(), Valid: true}},
+	}
+
+	approvals, rejections := db.CountSignatures(signatures)
+	require.Equal(t, int32(1), approvals)
+	require.Equal(t, int32(1), rejections)
+}
+
+func TestUpdateClearanceRecordStatusTxMultiSignature(t *testing.T) {
+	store := db.NewStore(testDB)
+	ctx := context.Background()
+	_, record := createRandomRequestRecord(t, false)
+	advisor, head := createRandomStaffUser(t), createRandomStaffUser(t)
+
+	_, err := testQueries.SetClearanceItemSignaturePolicy(ctx, db.SetClearanceItemSignaturePolicyParams{
+		ID:                 record.ClearanceItemID,
+		RequiredSignatures: 2,
+		RejectionPolicy:    db.RejectionPolicyBlock,
+	})
+	require.NoError(t, err)
+
+	sign := func(staff db.StaffUser, status string) (db.UpdateClearanceRecordStatusTxResult, error) {
+		return store.UpdateClearanceRecordStatusTx(ctx, db.UpdateClearanceRecordStatusTxParams{
+			RecordID:      record.ID,
+			Status:        status,
+			HandledBy:     sql.NullInt64{Int64: staff.ID, Valid: true},
+			ActorRole:     "staff",
+			ActorID:       staff.ID,
+			SignerStaffID: sql.NullInt64{Int64: staff.ID, Valid: true},
+		})
+	}
+
+	// a single signer cannot waive the item for everyone
+	_, err = sign(advisor, db.RecordStatusWaived)
+	require.ErrorIs(t, err, db.ErrSignatureDecisionOnly)
+
+	// one approval is not enough
+	result, err := sign(advisor, db.RecordStatusApproved)
+	require.NoError(t, err)
+	require.True(t, result.AwaitingSignatures)
+	require.Equal(t, db.RecordStatusPending, result.Record.Status)
+
+	// a rejection is applied right away and blocks later approvals
+	result, err = sign(head, db.RecordStatusRejected)
+	require.NoError(t, err)
+	require.Equal(t, db.RecordStatusRejected, result.Record.Status)
+
+	_, err = store.UpdateClearanceRecordStatusTx(ctx, db.UpdateClearanceRecordStatusTxParams{
+		RecordID:  record.ID,
+		Status:    db.RecordStatusResubmitted,
+		ActorRole: "student",
+		ActorID:   record.StudentID,
+	})
+	require.NoError(t, err)
+
+	_, err = sign(advisor, db.RecordStatusApproved)
+	require.ErrorIs(t, err, db.ErrSignatureBlocked)
+
+	// the rejecting signer changing their mind completes the item
+	result, err = sign(head, db.RecordStatusApproved)
+	require.NoError(t, err)
+	require.False(t, result.AwaitingSignatures)
+	require.Equal(t, db.RecordStatusApproved, result.Record.Status)
+
+	signatures, err := testQueries.ListRecordSignatures(ctx, record.ID)
+	require.NoError(t, err)
+	require.Len(t, signatures, 3)
+}
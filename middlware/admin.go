@@ -9,7 +9,7 @@ import (
 
 func AdminOnly() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
-		payload := ctx.MustGet("authorization_payload").(*token.Payload)
+		payload := ctx.MustGet(AuthorizationPayloadKey).(*token.Payload)
 
 		if payload.Role != "admin" {
 			ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{
@@ -30,7 +30,7 @@ func AuthMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
 			return
 		}
 
-		ctx.Set("payload", payload)
+		ctx.Set(AuthorizationPayloadKey, payload)
 		ctx.Set("role", payload.Role)
 		ctx.Set("user_id", payload.ID)
 		ctx.Next()
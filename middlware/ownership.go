@@ -0,0 +1,64 @@
+package middlware
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/backendn/clearance_system/token"
+	"github.com/gin-gonic/gin"
+)
+
+// AuthorizationPayloadKey is the context key AuthMiddleware stores the token payload under.
+const AuthorizationPayloadKey = "payload"
+
+// ErrForbidden is the body returned for every ownership denial.
+var ErrForbidden = gin.H{"error": "forbidden: you do not have access to this resource"}
+
+// GetPayload returns the token payload set by AuthMiddleware, or nil.
+func GetPayload(ctx *gin.Context) *token.Payload {
+	value, exists := ctx.Get(AuthorizationPayloadKey)
+	if !exists {
+		return nil
+	}
+
+	payload, _ := value.(*token.Payload)
+	return payload
+}
+
+// SelfOrRoles allows a request when the path parameter param matches the
+// caller's user ID and the caller has role selfRole, or when the caller has
+// one of the otherRoles. Everything else is rejected with 403.
+//
+// For example SelfOrRoles("id", "student", "staff", "admin") lets a student
+// reach only their own :id while staff and admins pass through.
+func SelfOrRoles(param string, selfRole string, otherRoles ...string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		payload := GetPayload(ctx)
+		if payload == nil {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing token payload"})
+			return
+		}
+
+		for _, role := range otherRoles {
+			if payload.Role == role {
+				ctx.Next()
+				return
+			}
+		}
+
+		if payload.Role == selfRole {
+			id, err := strconv.ParseInt(ctx.Param(param), 10, 64)
+			if err != nil {
+				ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid id parameter"})
+				return
+			}
+
+			if id == payload.UserID {
+				ctx.Next()
+				return
+			}
+		}
+
+		ctx.AbortWithStatusJSON(http.StatusForbidden, ErrForbidden)
+	}
+}
@@ -0,0 +1,68 @@
+package middlware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/time/rate"
+)
+
+// clientIdleTTL is how long a client's limiter is kept after its last request.
+const clientIdleTTL = 10 * time.Minute
+
+type rateLimitedClient struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// RateLimit allows each client IP limit requests per second with bursts of
+// up to burst requests. Clients over the limit get 429 Too Many Requests.
+// Limits are kept in memory, so they apply per server instance.
+func RateLimit(limit rate.Limit, burst int) gin.HandlerFunc {
+	var (
+		mu        sync.Mutex
+		clients   = map[string]*rateLimitedClient{}
+		lastSweep = time.Now()
+	)
+
+	return func(ctx *gin.Context) {
+		now := time.Now()
+		ip := ctx.ClientIP()
+
+		mu.Lock()
+		// forget idle clients so the map does not grow without bound
+		if now.Sub(lastSweep) > clientIdleTTL {
+			for key, client := range clients {
+				if now.Sub(client.lastSeen) > clientIdleTTL {
+					delete(clients, key)
+				}
+			}
+			lastSweep = now
+		}
+		client, ok := clients[ip]
+		if !ok {
+			client = &rateLimitedClient{limiter: rate.NewLimiter(limit, burst)}
+			clients[ip] = client
+		}
+		client.lastSeen = now
+		reservation := client.limiter.ReserveN(now, 1)
+		delay := reservation.DelayFrom(now)
+		if delay > 0 {
+			// a rejected request does not use up the client's allowance
+			reservation.CancelAt(now)
+		}
+		mu.Unlock()
+
+		if delay > 0 {
+			ctx.Header("Retry-After", strconv.Itoa(int(math.Ceil(delay.Seconds()))))
+			ctx.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "too many requests, please try again later"})
+			return
+		}
+
+		ctx.Next()
+	}
+}
@@ -0,0 +1,107 @@
+package payment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// FakeGateway is a local payment provider for development and tests. It
+// never moves money: payments are completed by calling Complete, which
+// returns a signed callback just like a real provider would send.
+type FakeGateway struct {
+	secret []byte
+	now    func() time.Time
+
+	mu        sync.Mutex
+	checkouts map[string]fakeCheckout // by reference
+}
+
+type fakeCheckout struct {
+	Checkout
+	req CheckoutRequest
+}
+
+func NewFakeGateway(webhookSecret string) (Gateway, error) {
+	if webhookSecret == "" {
+		return nil, errors.New("payment webhook secret is required")
+	}
+	return &FakeGateway{
+		secret:    []byte(webhookSecret),
+		now:       time.Now,
+		checkouts: make(map[string]fakeCheckout),
+	}, nil
+}
+
+func (g *FakeGateway) Name() string {
+	return "fake"
+}
+
+// CreateCheckout returns the existing checkout for a reference it has seen
+// before, like providers that honour idempotency keys.
+func (g *FakeGateway) CreateCheckout(ctx context.Context, req CheckoutRequest) (Checkout, error) {
+	if req.Reference == "" || req.Amount <= 0 {
+		return Checkout{}, errors.New("reference and a positive amount are required")
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if existing, ok := g.checkouts[req.Reference]; ok {
+		return existing.Checkout, nil
+	}
+
+	id := fmt.Sprintf("fake_%d", len(g.checkouts)+1)
+	checkout := Checkout{
+		ProviderPaymentID: id,
+		URL:               "fake://checkout/" + id,
+	}
+	g.checkouts[req.Reference] = fakeCheckout{Checkout: checkout, req: req}
+	return checkout, nil
+}
+
+func (g *FakeGateway) ParseWebhook(header http.Header, body []byte) (Event, error) {
+	if err := VerifySignature(g.secret, header.Get(SignatureHeader), body, g.now()); err != nil {
+		return Event{}, err
+	}
+
+	var event Event
+	if err := json.Unmarshal(body, &event); err != nil {
+		return Event{}, fmt.Errorf("%w: %v", ErrMalformedEvent, err)
+	}
+	if event.Reference == "" || (event.Status != StatusSucceeded && event.Status != StatusFailed) {
+		return Event{}, ErrMalformedEvent
+	}
+	return event, nil
+}
+
+// Complete finishes a checkout with the given status and returns the
+// signed callback the provider would post to the webhook endpoint.
+func (g *FakeGateway) Complete(reference, status string) (http.Header, []byte, error) {
+	g.mu.Lock()
+	checkout, ok := g.checkouts[reference]
+	g.mu.Unlock()
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown payment reference %q", reference)
+	}
+
+	body, err := json.Marshal(Event{
+		Reference:         reference,
+		ProviderPaymentID: checkout.ProviderPaymentID,
+		Status:            status,
+		Amount:            checkout.req.Amount,
+		Currency:          checkout.req.Currency,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	header.Set(SignatureHeader, Sign(g.secret, g.now(), body))
+	return header, body, nil
+}
@@ -0,0 +1,88 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/backendn/clearance_system/util"
+)
+
+// Statuses a provider reports for a payment in its callbacks.
+const (
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+)
+
+var (
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrMalformedEvent   = errors.New("malformed webhook event")
+)
+
+// CheckoutRequest describes a payment to collect.
+type CheckoutRequest struct {
+	// Reference is our idempotent reference for the payment. Providers
+	// must return the same checkout when it is sent twice.
+	Reference   string
+	Amount      int64 // in the currency's minor unit
+	Currency    string
+	Description string
+}
+
+// Checkout is a payment started at the provider.
+type Checkout struct {
+	ProviderPaymentID string
+	// URL is where the student completes the payment.
+	URL string
+}
+
+// Event is a verified payment callback.
+type Event struct {
+	Reference         string `json:"reference"`
+	ProviderPaymentID string `json:"provider_payment_id"`
+	Status            string `json:"status"`
+	Amount            int64  `json:"amount"`
+	Currency          string `json:"currency"`
+}
+
+// Gateway is a payment provider.
+type Gateway interface {
+	// Name identifies the provider in stored payments.
+	Name() string
+	// CreateCheckout starts collecting a payment.
+	CreateCheckout(ctx context.Context, req CheckoutRequest) (Checkout, error)
+	// ParseWebhook verifies the signature of a callback and decodes it.
+	ParseWebhook(header http.Header, body []byte) (Event, error)
+}
+
+// publishedWebhookSecrets are webhook secrets that were committed to the
+// repository. Anyone could sign callbacks with them, so they are never
+// accepted.
+var publishedWebhookSecrets = map[string]bool{
+	"change-me-payment-webhook-secret": true,
+}
+
+// New creates the gateway selected by config.PaymentProvider. The fake
+// provider completes payments for whoever holds the webhook secret, so it
+// is only available when config.PaymentFakeEnabled is set.
+func New(config util.Config) (Gateway, error) {
+	if config.PaymentWebhookSecret == "" {
+		return nil, errors.New("payment webhook secret is required; generate one with `openssl rand -base64 32`")
+	}
+	if publishedWebhookSecrets[config.PaymentWebhookSecret] {
+		return nil, errors.New("payment webhook secret is a published example secret; generate a new one")
+	}
+
+	switch config.PaymentProvider {
+	case "":
+		return nil, errors.New("payment provider is required")
+	case "fake":
+		if !config.PaymentFakeEnabled {
+			return nil, errors.New("the fake payment provider is for development only; set PAYMENT_FAKE_ENABLED=true to use it")
+		}
+		return NewFakeGateway(config.PaymentWebhookSecret)
+	default:
+		return nil, fmt.Errorf("unknown payment provider %q", config.PaymentProvider)
+	}
+}
@@ -0,0 +1,79 @@
+package payment
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/backendn/clearance_system/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifySignature(t *testing.T) {
+	secret := []byte("webhook-secret")
+	body := []byte(`{"reference":"abc"}`)
+	now := time.Now()
+
+	header := Sign(secret, now, body)
+	require.NoError(t, VerifySignature(secret, header, body, now))
+
+	require.ErrorIs(t, VerifySignature([]byte("other"), header, body, now), ErrInvalidSignature)
+	require.ErrorIs(t, VerifySignature(secret, header, []byte(`{"reference":"xyz"}`), now), ErrInvalidSignature)
+	require.ErrorIs(t, VerifySignature(secret, header, body, now.Add(time.Hour)), ErrInvalidSignature)
+	require.ErrorIs(t, VerifySignature(secret, "v1=deadbeef", body, now), ErrInvalidSignature)
+}
+
+func TestFakeGateway(t *testing.T) {
+	gateway, err := NewFakeGateway("webhook-secret")
+	require.NoError(t, err)
+	fake := gateway.(*FakeGateway)
+
+	req := CheckoutRequest{Reference: "ref-1", Amount: 2500, Currency: "USD"}
+	checkout, err := fake.CreateCheckout(context.Background(), req)
+	require.NoError(t, err)
+	require.NotEmpty(t, checkout.URL)
+
+	// the same reference gives the same checkout
+	again, err := fake.CreateCheckout(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, checkout, again)
+
+	header, body, err := fake.Complete("ref-1", StatusSucceeded)
+	require.NoError(t, err)
+
+	event, err := fake.ParseWebhook(header, body)
+	require.NoError(t, err)
+	require.Equal(t, Event{
+		Reference:         "ref-1",
+		ProviderPaymentID: checkout.ProviderPaymentID,
+		Status:            StatusSucceeded,
+		Amount:            2500,
+		Currency:          "USD",
+	}, event)
+
+	header.Set(SignatureHeader, "t=1,v1=00")
+	_, err = fake.ParseWebhook(header, body)
+	require.ErrorIs(t, err, ErrInvalidSignature)
+}
+
+func TestNewRefusesUnsafeConfig(t *testing.T) {
+	config := util.Config{PaymentProvider: "fake", PaymentWebhookSecret: "webhook-secret"}
+
+	// the fake provider has to be asked for
+	_, err := New(config)
+	require.Error(t, err)
+
+	config.PaymentFakeEnabled = true
+	gateway, err := New(config)
+	require.NoError(t, err)
+	require.Equal(t, "fake", gateway.Name())
+
+	for _, secret := range []string{"", "change-me-payment-webhook-secret"} {
+		config.PaymentWebhookSecret = secret
+		_, err = New(config)
+		require.Error(t, err, secret)
+	}
+
+	_, err = New(util.Config{PaymentWebhookSecret: "webhook-secret", PaymentFakeEnabled: true})
+	require.Error(t, err)
+}
@@ -0,0 +1,66 @@
+package payment
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SignatureHeader carries the signature of a webhook body.
+const SignatureHeader = "X-Payment-Signature"
+
+// signatureTolerance is how old a signed callback may be, which limits
+// replays of captured requests.
+const signatureTolerance = 5 * time.Minute
+
+// Sign returns the signature header value for a webhook body sent at
+// timestamp, in the form "t=<unix seconds>,v1=<hex HMAC-SHA256>". The MAC
+// covers the timestamp and the body so neither can be changed on its own.
+func Sign(secret []byte, timestamp time.Time, body []byte) string {
+	t := strconv.FormatInt(timestamp.Unix(), 10)
+	return fmt.Sprintf("t=%s,v1=%s", t, mac(secret, t, body))
+}
+
+// VerifySignature checks a signature header produced by Sign against the
+// body. Signatures older or newer than the tolerance are rejected.
+func VerifySignature(secret []byte, header string, body []byte, now time.Time) error {
+	var t, v1 string
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			t = value
+		case "v1":
+			v1 = value
+		}
+	}
+	if t == "" || v1 == "" {
+		return ErrInvalidSignature
+	}
+
+	unix, err := strconv.ParseInt(t, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	age := now.Sub(time.Unix(unix, 0))
+	if age > signatureTolerance || age < -signatureTolerance {
+		return fmt.Errorf("%w: timestamp outside tolerance", ErrInvalidSignature)
+	}
+
+	if !hmac.Equal([]byte(v1), []byte(mac(secret, t, body))) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func mac(secret []byte, timestamp string, body []byte) string {
+	h := hmac.New(sha256.New, secret)
+	h.Write([]byte(timestamp))
+	h.Write([]byte("."))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
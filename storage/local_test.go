@@ -0,0 +1,47 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStorage(t *testing.T) {
+	store, err := NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	content := "hello clearance"
+
+	err = store.Put(ctx, "records/1/file.txt", strings.NewReader(content), int64(len(content)), "text/plain")
+	require.NoError(t, err)
+
+	r, err := store.Get(ctx, "records/1/file.txt")
+	require.NoError(t, err)
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	require.Equal(t, content, string(data))
+
+	// a short body is rejected and leaves nothing behind
+	err = store.Put(ctx, "records/1/short.txt", strings.NewReader("abc"), 10, "text/plain")
+	require.Error(t, err)
+	_, err = store.Get(ctx, "records/1/short.txt")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, store.Delete(ctx, "records/1/file.txt"))
+	require.NoError(t, store.Delete(ctx, "records/1/file.txt"))
+	_, err = store.Get(ctx, "records/1/file.txt")
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestValidateKey(t *testing.T) {
+	require.NoError(t, validateKey("records/1/abc.pdf"))
+
+	for _, key := range []string{"", "/etc/passwd", "../secret", "records/../../x", "records//x", `records\x`} {
+		require.ErrorIs(t, validateKey(key), ErrInvalidKey, key)
+	}
+}
@@ -0,0 +1,47 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestS3Storage runs against a real S3-compatible server, e.g. the MinIO
+// container started by `make minio`. It is skipped unless S3_TEST_ENDPOINT
+// is set; the bucket must already exist.
+func TestS3Storage(t *testing.T) {
+	endpoint := os.Getenv("S3_TEST_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("S3_TEST_ENDPOINT not set")
+	}
+
+	store, err := NewS3Storage(S3Config{
+		Endpoint:        endpoint,
+		Bucket:          os.Getenv("S3_TEST_BUCKET"),
+		AccessKeyID:     os.Getenv("S3_TEST_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("S3_TEST_SECRET_ACCESS_KEY"),
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	key := "tests/hello world+1.txt"
+	content := "hello clearance"
+
+	err = store.Put(ctx, key, strings.NewReader(content), int64(len(content)), "text/plain")
+	require.NoError(t, err)
+
+	r, err := store.Get(ctx, key)
+	require.NoError(t, err)
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	require.Equal(t, content, string(data))
+
+	require.NoError(t, store.Delete(ctx, key))
+	_, err = store.Get(ctx, key)
+	require.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,57 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/backendn/clearance_system/util"
+)
+
+var ErrNotFound = errors.New("object not found")
+var ErrInvalidKey = errors.New("invalid object key")
+
+// Storage is an interface for storing uploaded files
+type Storage interface {
+	// Put stores size bytes read from r under key, replacing any existing object.
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Get opens the object stored under key. The caller must close it.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the object stored under key. Missing objects are not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// New creates the storage backend selected by config.StorageBackend.
+func New(config util.Config) (Storage, error) {
+	switch config.StorageBackend {
+	case "", "local":
+		return NewLocalStorage(config.StorageLocalDir)
+	case "s3":
+		return NewS3Storage(S3Config{
+			Endpoint:        config.S3Endpoint,
+			Region:          config.S3Region,
+			Bucket:          config.S3Bucket,
+			AccessKeyID:     config.S3AccessKeyID,
+			SecretAccessKey: config.S3SecretAccessKey,
+			UseSSL:          config.S3UseSSL,
+		})
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", config.StorageBackend)
+	}
+}
+
+// validateKey rejects empty keys, absolute keys and keys that try to escape
+// the storage root.
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return ErrInvalidKey
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return ErrInvalidKey
+		}
+	}
+	return nil
+}
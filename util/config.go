@@ -8,6 +8,36 @@ type Config struct {
 	DBDriver      string `mapstructure:"DB_DRIVER"`
 	DBSource      string `mapstructure:"DB_SOURCE"`
 	ServerAddress string `mapstructure:"SERVER_ADDRESS"`
+	// Comma separated addresses or CIDRs of reverse proxies whose
+	// X-Forwarded-For header is believed; empty trusts none
+	TrustedProxies []string `mapstructure:"TRUSTED_PROXIES"`
+
+	// Attachment storage: "local" or "s3"
+	StorageBackend    string `mapstructure:"STORAGE_BACKEND"`
+	StorageLocalDir   string `mapstructure:"STORAGE_LOCAL_DIR"`
+	MaxUploadSize     int64  `mapstructure:"MAX_UPLOAD_SIZE"` // bytes
+	S3Endpoint        string `mapstructure:"S3_ENDPOINT"`
+	S3Region          string `mapstructure:"S3_REGION"`
+	S3Bucket          string `mapstructure:"S3_BUCKET"`
+	S3AccessKeyID     string `mapstructure:"S3_ACCESS_KEY_ID"`
+	S3SecretAccessKey string `mapstructure:"S3_SECRET_ACCESS_KEY"`
+	S3UseSSL          bool   `mapstructure:"S3_USE_SSL"`
+
+	// How often a student may resubmit a rejected record; 0 means no limit
+	MaxResubmissions int64 `mapstructure:"MAX_RESUBMISSIONS"`
+
+	// Payment gateway for obligation fees: "fake" is the only provider so
+	// far, and it has to be enabled explicitly as it is for development only
+	PaymentProvider      string `mapstructure:"PAYMENT_PROVIDER"`
+	PaymentFakeEnabled   bool   `mapstructure:"PAYMENT_FAKE_ENABLED"`
+	PaymentWebhookSecret string `mapstructure:"PAYMENT_WEBHOOK_SECRET"`
+
+	// Base64 encoded 32 byte Ed25519 seed used to sign clearance
+	// certificates; generate one per deployment with `make signingkey`
+	CertificateSigningKey string `mapstructure:"CERTIFICATE_SIGNING_KEY"`
+	// Public address of GET /verify, printed on certificates with each
+	// certificate's code appended
+	CertificateVerifyURL string `mapstructure:"CERTIFICATE_VERIFY_URL"`
 }
 
 func LoadConfig(path string) (config Config, err error) {